@@ -2,6 +2,9 @@ package gointerview
 
 import (
 	"io"
+	"math/rand/v2"
+	r "reflect"
+	"testing"
 
 	ite "github.com/Matej-Chmel/go-interview/internal"
 )
@@ -10,7 +13,7 @@ import (
 // Delegates all implementation to Interview2
 // with a dummy type for the second input.
 type Interview[I any, O any] struct {
-	ite.EmbeddedOptions
+	*ite.EmbeddedOptions
 	iv Interview2[I, int, O]
 }
 
@@ -20,7 +23,7 @@ func NewInterview[I any, O any]() Interview[I, O] {
 
 	return Interview[I, O]{
 		EmbeddedOptions: options,
-		iv:              newInterview2Impl[I, int, O](true, &options),
+		iv:              newInterview2Impl[I, int, O](true, options),
 	}
 }
 
@@ -29,6 +32,12 @@ func (iv *Interview[I, O]) AddCase(input I, expected O) {
 	iv.iv.AddCase(input, 0, expected)
 }
 
+// Adds one test case that accepts any of the given outputs.
+// Panics if no output is given.
+func (iv *Interview[I, O]) AddCaseAlternatives(input I, accepted ...O) {
+	iv.iv.AddCaseAlternatives(input, 0, accepted...)
+}
+
 // Converts strings to a byte or rune slices and attempts
 // to add those slices as a new test case.
 // Panics if any string cannot be converted to its target type.
@@ -46,9 +55,67 @@ func (iv *Interview[I, O]) AddCasesSlice(input []I, expected []O, begin, end int
 	iv.iv.AddCasesSlice(input, []int{}, expected, begin, end)
 }
 
-// Adds one solution function
+// Adds one test case that expects an error.
+// The returned error matches if errors.Is reports so
+// or if its message equals the message of the expected error.
+func (iv *Interview[I, O]) AddErrorCase(input I, expected error) {
+	iv.iv.AddErrorCase(input, 0, expected)
+}
+
+// Adds one solution function that returns an error for invalid input
+func (iv *Interview[I, O]) AddErrorSolution(s func(I) (O, error)) {
+	iv.iv.addSolution1(ite.GetFunctionName(s), s)
+}
+
+// Adds multiple solution functions that return an error for invalid input
+func (iv *Interview[I, O]) AddErrorSolutions(s ...func(I) (O, error)) {
+	for _, f := range s {
+		iv.AddErrorSolution(f)
+	}
+}
+
+// Adds one solution function that modifies its input in place.
+// The modified input is then compared as the actual output.
+// Panics if the output type differs from the input type.
+func (iv *Interview[I, O]) AddInPlaceSolution(s func(I)) {
+	checkInPlace[I, O]()
+	name := ite.GetFunctionName(s)
+	iv.iv.addSolution1(name, func(input I) (O, error) {
+		s(input)
+		return any(input).(O), nil
+	})
+	iv.iv.inPlace[name] = true
+}
+
+// Adds multiple solution functions that modify their input in place
+func (iv *Interview[I, O]) AddInPlaceSolutions(s ...func(I)) {
+	for _, f := range s {
+		iv.AddInPlaceSolution(f)
+	}
+}
+
+// Adds one test case without an expected output.
+// The expected output is computed by the reference solution.
+func (iv *Interview[I, O]) AddInput(input I) {
+	iv.iv.AddInput(input, 0)
+}
+
+// Adds multiple test cases without expected outputs.
+// The expected outputs are computed by the reference solution.
+func (iv *Interview[I, O]) AddInputs(input []I) {
+	iv.iv.AddInputs(input, nil)
+}
+
+// Adds one solution function named after the function.
+// Panics if a solution with the same name was already added.
 func (iv *Interview[I, O]) AddSolution(s func(I) O) {
-	iv.iv.solutions1[ite.GetFunctionName(s)] = s
+	iv.iv.addSolution1(ite.GetFunctionName(s), noError1(s))
+}
+
+// Adds one solution function under the given name.
+// Panics if a solution with the same name was already added.
+func (iv *Interview[I, O]) AddNamedSolution(name string, s func(I) O) {
+	iv.iv.addSolution1(name, noError1(s))
 }
 
 // Adds multiple solution functions
@@ -58,12 +125,69 @@ func (iv *Interview[I, O]) AddSolutions(s ...func(I) O) {
 	}
 }
 
+// Adds one solution function returning multiple values.
+// The values are collected into the output, which must be a struct
+// such as Pair or Triple with fields of the returned types in order.
+// The values can be followed by an error.
+// Panics if s doesn't have such a signature.
+func (iv *Interview[I, O]) AddTupleSolution(s any) {
+	f := wrapTuple[O](s, r.TypeFor[I]())
+	name := ite.GetFunctionName(s)
+
+	iv.iv.addSolution1(name, func(input I) (O, error) {
+		return f(r.ValueOf(&input).Elem())
+	})
+	iv.iv.reflective[name] = true
+}
+
+// Adds multiple solution functions returning multiple values
+func (iv *Interview[I, O]) AddTupleSolutions(s ...any) {
+	for _, f := range s {
+		iv.AddTupleSolution(f)
+	}
+}
+
 // Runs all solutions against all test cases
 // and compiles the output into a single string
 func (iv *Interview[I, O]) AllSolutionsToString() string {
 	return iv.iv.AllSolutionsToString()
 }
 
+// Sets a short description of the named solution
+// that is shown under its name in the output.
+// If the solution cannot be found, an error is returned.
+func (iv *Interview[I, O]) DescribeSolution(name, description string) error {
+	return iv.iv.DescribeSolution(name, description)
+}
+
+// Ignores the order of elements of an output slice or array.
+// For map outputs, the order of elements of slices stored
+// as map values is ignored. Nested slices keep their order.
+func (iv *Interview[I, O]) IgnoreOrder() {
+	iv.iv.IgnoreOrder()
+}
+
+// Ignores the order of elements of all slices and arrays
+// at every level of the output
+func (iv *Interview[I, O]) IgnoreNestedOrder() {
+	iv.iv.IgnoreNestedOrder()
+}
+
+// Registers a fuzz target that runs all solutions on inputs decoded from
+// the fuzzing data and compares their outputs with the reference.
+// The corpus is seeded from the inputs of added test cases.
+func (iv *Interview[I, O]) Fuzz(f *testing.F, reference func(I) O) {
+	f.Helper()
+	iv.iv.fuzzImpl(f, iv.iv.prepareFunction1(noError1(reference), false))
+}
+
+// Runs all solutions except the reference against random inputs
+// and prints the minimal failing input of each solution
+// to the standard output
+func (iv *Interview[I, O]) PrintRandomTest(runs int, seed uint64) error {
+	return iv.iv.PrintRandomTest(runs, seed)
+}
+
 // Runs all solutions against all test cases
 // and prints the output to the standard output
 func (iv *Interview[I, O]) Print() error {
@@ -88,17 +212,128 @@ func (iv *Interview[I, O]) ReadCasesSlice(
 	iv.iv.ReadCasesSlice(inputRelPath, "", expectedRelPath, begin, end)
 }
 
+// Reads multiple cases without expected outputs from a relative path.
+// The expected outputs are computed by the reference solution.
+func (iv *Interview[I, O]) ReadInputs(inputRelPath string) {
+	iv.iv.ReadInputs(inputRelPath, "")
+}
+
+// Runs all solutions except the reference against random inputs.
+// Each output is checked by the property if it is set or compared
+// against the output of the reference solution. If a solution fails,
+// its failing input is shrunk to a minimal one that still fails.
+// The same seed always produces the same inputs.
+// Panics if neither the property nor the reference solution is set.
+func (iv *Interview[I, O]) RandomTest(runs int, seed uint64) ite.RandomReceiptSlice {
+	return iv.iv.RandomTest(runs, seed)
+}
+
 // Runs one solution function against all test cases.
 // If function cannot be found, an error is returned.
 func (iv *Interview[I, O]) RunSolution(name string) (ite.Receipt, error) {
 	return iv.iv.RunSolution(name)
 }
 
+// Runs a sub-benchmark for every pair of a solution and a test case
+// named after the solution and the case index
+func (iv *Interview[I, O]) RunBenchmarks(b *testing.B) {
+	b.Helper()
+	iv.iv.RunBenchmarks(b)
+}
+
 // Runs all solutions against all test cases
 func (iv *Interview[I, O]) RunAllSolutions() ite.ReceiptSlice {
 	return iv.iv.RunAllSolutions()
 }
 
+// Runs every solution as a subtest named after the solution
+// with one subtest per test case named after the case index
+func (iv *Interview[I, O]) RunTests(t *testing.T) {
+	t.Helper()
+	iv.iv.RunTests(t)
+}
+
+// Sets a directory relative to the main Go file where minimal failing
+// inputs found by random testing are saved. Files can be read back
+// by ReadCase. Empty string disables saving.
+func (iv *Interview[I, O]) SaveRandomCases(relDir string) {
+	iv.iv.SaveRandomCases(relDir)
+}
+
+// Marks a registered solution as the reference. Expected outputs
+// of cases added without them are computed by this solution
+// and all other solutions are compared against them.
+// If the solution cannot be found, an error is returned.
+func (iv *Interview[I, O]) SetReference(name string) error {
+	return iv.iv.SetReference(name)
+}
+
+// Sets a function that decides whether the actual output
+// of a solution matches the expected one.
+// Nil restores the default typed deep equality.
+func (iv *Interview[I, O]) SetComparator(comparator func(actual, expected O) bool) {
+	iv.iv.SetComparator(comparator)
+}
+
+// Registers a generator of inputs of size n. Every solution is then run
+// on inputs of all given sizes and its time complexity is estimated
+// and shown next to its name. At least three sizes are required.
+func (iv *Interview[I, O]) SetGenerator(generator func(n int) I, sizes ...int) {
+	if generator == nil {
+		iv.iv.SetGenerator(nil, sizes...)
+		return
+	}
+
+	iv.iv.SetGenerator(func(n int) (I, int) {
+		return generator(n), 0
+	}, sizes...)
+}
+
+// Sets a property that every output of a solution must satisfy.
+// Random testing then checks the property instead of comparing outputs
+// against the reference solution. Nil removes the property.
+func (iv *Interview[I, O]) SetProperty(property func(input I, actual O) bool) {
+	if property == nil {
+		iv.iv.SetProperty(nil)
+		return
+	}
+
+	iv.iv.SetProperty(func(input I, _ int, actual O) bool {
+		return property(input, actual)
+	})
+}
+
+// Sets a generator of random inputs. The size grows from 1 to 100
+// during the test. If no generator is set, inputs are generated
+// by reflection.
+func (iv *Interview[I, O]) SetRandomGenerator(
+	generator func(rng *rand.Rand, size int) I,
+) {
+	if generator == nil {
+		iv.iv.SetRandomGenerator(nil)
+		return
+	}
+
+	iv.iv.SetRandomGenerator(func(rng *rand.Rand, size int) (I, int) {
+		return generator(rng, size), 0
+	})
+}
+
+// Sets a validator that checks the output of a solution instead
+// of comparing it with the expected output. An error returned
+// by the validator marks the case as failed and its message is shown.
+// Cases can then be added with inputs only. Nil removes the validator.
+func (iv *Interview[I, O]) SetValidator(validator func(input I, actual O) error) {
+	if validator == nil {
+		iv.iv.SetValidator(nil)
+		return
+	}
+
+	iv.iv.SetValidator(func(input I, _ int, actual O) error {
+		return validator(input, actual)
+	})
+}
+
 // Runs all solutions against all test cases
 // and writes the results into a writer w
 func (iv *Interview[I, O]) WriteAllSolutions(w io.Writer) error {
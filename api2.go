@@ -3,11 +3,12 @@ package gointerview
 import (
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"os"
+	r "reflect"
 	"strings"
+	"time"
 
-	at "github.com/Matej-Chmel/go-any-to-string"
-	dc "github.com/Matej-Chmel/go-deep-copy"
 	ite "github.com/Matej-Chmel/go-interview/internal"
 )
 
@@ -15,17 +16,29 @@ import (
 // It can act as an implementation for Interview class.
 type Interview2[I any, I2 any, O any] struct {
 	*ite.EmbeddedOptions
-	byteFlags     uint
-	cases         []*ite.TestCase[I, I2, O]
-	isSingleInput bool
-	solutions1    map[string]func(I) O
-	solutions2    map[string]func(I, I2) O
+	byteFlags       uint
+	cases           []*ite.TestCase[I, I2, O]
+	comparator      func(actual, expected O) bool
+	descriptions    map[string]string
+	generated       []*ite.TestCase[I, I2, O]
+	generator       func(n int) (I, I2)
+	inPlace         map[string]bool
+	isSingleInput   bool
+	order           ite.Order
+	property        func(I, I2, O) bool
+	randomCaseDir   string
+	randomGenerator func(rng *rand.Rand, size int) (I, I2)
+	reference       string
+	reflective      map[string]bool
+	sizes           []int
+	solutions1      *ite.Registry[func(I) (O, error)]
+	solutions2      *ite.Registry[func(I, I2) (O, error)]
+	validator       func(I, I2, O) error
 }
 
 // Constructs an Interview2 object
 func NewInterview2[I any, I2 any, O any]() Interview2[I, I2, O] {
-	options := ite.NewEmbeddedOptions()
-	return newInterview2Impl[I, I2, O](false, &options)
+	return newInterview2Impl[I, I2, O](false, ite.NewEmbeddedOptions())
 }
 
 // Internal constructor for Interview2 object.
@@ -37,10 +50,23 @@ func newInterview2Impl[I any, I2 any, O any](
 	res := Interview2[I, I2, O]{
 		byteFlags:       0,
 		cases:           make([]*ite.TestCase[I, I2, O], 0),
+		comparator:      nil,
+		descriptions:    make(map[string]string),
 		EmbeddedOptions: options,
+		generated:       nil,
+		generator:       nil,
+		inPlace:         make(map[string]bool),
 		isSingleInput:   isSingleInput,
+		order:           ite.Ordered,
+		property:        nil,
+		randomCaseDir:   "",
+		randomGenerator: nil,
+		reference:       "",
+		reflective:      make(map[string]bool),
+		sizes:           nil,
 		solutions1:      nil,
 		solutions2:      nil,
+		validator:       nil,
 	}
 
 	if ite.IsByte[I]() {
@@ -56,9 +82,9 @@ func newInterview2Impl[I any, I2 any, O any](
 	}
 
 	if isSingleInput {
-		res.solutions1 = make(map[string]func(I) O)
+		res.solutions1 = ite.NewRegistry[func(I) (O, error)]()
 	} else {
-		res.solutions2 = make(map[string]func(I, I2) O)
+		res.solutions2 = ite.NewRegistry[func(I, I2) (O, error)]()
 	}
 
 	return res
@@ -70,6 +96,16 @@ func (iv *Interview2[I, I2, O]) AddCase(input I, input2 I2, expected O) {
 	iv.cases = append(iv.cases, testCase)
 }
 
+// Adds one test case that accepts any of the given outputs.
+// Panics if no output is given.
+func (iv *Interview2[I, I2, O]) AddCaseAlternatives(
+	input I, input2 I2, accepted ...O,
+) {
+	testCase := ite.NewAlternativesCase(
+		&input, &input2, accepted, iv.isSingleInput)
+	iv.cases = append(iv.cases, testCase)
+}
+
 // Converts strings to a byte or rune slices and attempts
 // to add those slices as a new test case.
 // Panics if any string cannot be converted to its target type.
@@ -132,9 +168,83 @@ func (iv *Interview2[I, I2, O]) AddCasesSlice(
 	}
 }
 
-// Adds one solution function
+// Adds one test case that expects an error.
+// The returned error matches if errors.Is reports so
+// or if its message equals the message of the expected error.
+func (iv *Interview2[I, I2, O]) AddErrorCase(input I, input2 I2, expected error) {
+	testCase := ite.NewErrorCase[I, I2, O](
+		&input, &input2, expected, iv.isSingleInput)
+	iv.cases = append(iv.cases, testCase)
+}
+
+// Adds one solution function that returns an error for invalid input
+func (iv *Interview2[I, I2, O]) AddErrorSolution(s func(I, I2) (O, error)) {
+	iv.addSolution2(ite.GetFunctionName(s), s)
+}
+
+// Adds multiple solution functions that return an error for invalid input
+func (iv *Interview2[I, I2, O]) AddErrorSolutions(s ...func(I, I2) (O, error)) {
+	for _, f := range s {
+		iv.AddErrorSolution(f)
+	}
+}
+
+// Adds one solution function that modifies its first input in place.
+// The modified input is then compared as the actual output.
+// Panics if the output type differs from the type of the first input.
+func (iv *Interview2[I, I2, O]) AddInPlaceSolution(s func(I, I2)) {
+	checkInPlace[I, O]()
+	name := ite.GetFunctionName(s)
+	iv.addSolution2(name, func(input I, input2 I2) (O, error) {
+		s(input, input2)
+		return any(input).(O), nil
+	})
+	iv.inPlace[name] = true
+}
+
+// Adds multiple solution functions that modify their first input in place
+func (iv *Interview2[I, I2, O]) AddInPlaceSolutions(s ...func(I, I2)) {
+	for _, f := range s {
+		iv.AddInPlaceSolution(f)
+	}
+}
+
+// Adds one test case without an expected output.
+// The expected output is computed by the reference solution.
+func (iv *Interview2[I, I2, O]) AddInput(input I, input2 I2) {
+	testCase := ite.NewInputCase[I, I2, O](&input, &input2, iv.isSingleInput)
+	iv.cases = append(iv.cases, testCase)
+}
+
+// Adds multiple test cases without expected outputs.
+// The expected outputs are computed by the reference solution.
+func (iv *Interview2[I, I2, O]) AddInputs(input1 []I, input2 []I2) {
+	if !iv.isSingleInput && len(input1) != len(input2) {
+		panic(fmt.Errorf(
+			"Length of inputs don't match %d:%d", len(input1), len(input2)))
+	}
+
+	var i2 I2
+
+	for i := range input1 {
+		if !iv.isSingleInput {
+			i2 = input2[i]
+		}
+
+		iv.AddInput(input1[i], i2)
+	}
+}
+
+// Adds one solution function named after the function.
+// Panics if a solution with the same name was already added.
 func (iv *Interview2[I, I2, O]) AddSolution(s func(I, I2) O) {
-	iv.solutions2[ite.GetFunctionName(s)] = s
+	iv.addSolution2(ite.GetFunctionName(s), noError2(s))
+}
+
+// Adds one solution function under the given name.
+// Panics if a solution with the same name was already added.
+func (iv *Interview2[I, I2, O]) AddNamedSolution(name string, s func(I, I2) O) {
+	iv.addSolution2(name, noError2(s))
 }
 
 // Adds multiple solution functions
@@ -144,6 +254,48 @@ func (iv *Interview2[I, I2, O]) AddSolutions(s ...func(I, I2) O) {
 	}
 }
 
+// Adds one solution function returning multiple values.
+// The values are collected into the output, which must be a struct
+// such as Pair or Triple with fields of the returned types in order.
+// The values can be followed by an error.
+// Panics if s doesn't have such a signature.
+func (iv *Interview2[I, I2, O]) AddTupleSolution(s any) {
+	f := wrapTuple[O](s, r.TypeFor[I](), r.TypeFor[I2]())
+	name := ite.GetFunctionName(s)
+
+	iv.addSolution2(name, func(input I, input2 I2) (O, error) {
+		return f(r.ValueOf(&input).Elem(), r.ValueOf(&input2).Elem())
+	})
+	iv.reflective[name] = true
+}
+
+// Adds multiple solution functions returning multiple values
+func (iv *Interview2[I, I2, O]) AddTupleSolutions(s ...any) {
+	for _, f := range s {
+		iv.AddTupleSolution(f)
+	}
+}
+
+// Registers a single input solution under name.
+// Panics if a solution with the same name was already added.
+func (iv *Interview2[I, I2, O]) addSolution1(name string, f func(I) (O, error)) {
+	panicIfDuplicate(iv.solutions1.Add(name, f))
+}
+
+// Registers a two input solution under name.
+// Panics if a solution with the same name was already added.
+func (iv *Interview2[I, I2, O]) addSolution2(name string, f func(I, I2) (O, error)) {
+	panicIfDuplicate(iv.solutions2.Add(name, f))
+}
+
+// Panics if a solution could not be added because of its name
+func panicIfDuplicate(err error) {
+	if err != nil {
+		panic(fmt.Errorf(
+			"%w, use AddNamedSolution to add it under a different name", err))
+	}
+}
+
 // Runs all solutions against all test cases
 // and compiles the output into a single string
 func (iv *Interview2[I, I2, O]) AllSolutionsToString() string {
@@ -152,35 +304,296 @@ func (iv *Interview2[I, I2, O]) AllSolutionsToString() string {
 	return builder.String()
 }
 
+// Sets a short description of the named solution
+// that is shown under its name in the output.
+// If the solution cannot be found, an error is returned.
+func (iv *Interview2[I, I2, O]) DescribeSolution(name, description string) error {
+	if !iv.hasSolution(name) {
+		return fmt.Errorf("solution %s not found", name)
+	}
+
+	iv.descriptions[name] = description
+	return nil
+}
+
+// Ignores the order of elements of an output slice or array.
+// For map outputs, the order of elements of slices stored
+// as map values is ignored. Nested slices keep their order.
+func (iv *Interview2[I, I2, O]) IgnoreOrder() {
+	iv.order = ite.UnorderedTop
+}
+
+// Ignores the order of elements of all slices and arrays
+// at every level of the output, so that for example a slice
+// of slices is compared as a multiset of multisets
+func (iv *Interview2[I, I2, O]) IgnoreNestedOrder() {
+	iv.order = ite.UnorderedNested
+}
+
+// Runs calls prepared by prepare against generated inputs of growing sizes
+// and fits the measured times to common complexity classes.
+// Inputs are generated once and shared by all solutions.
+func (iv *Interview2[I, I2, O]) estimateComplexity(
+	prepare func(c *ite.TestCase[I, I2, O]) ite.Prepared[O], config ite.CallConfig,
+) *ite.Complexity {
+	if iv.generated == nil {
+		iv.generated = make([]*ite.TestCase[I, I2, O], len(iv.sizes))
+
+		for i, n := range iv.sizes {
+			var expected O
+			input, input2 := iv.generator(n)
+			iv.generated[i] = ite.NewTestCase(
+				&input, &input2, &expected, iv.isSingleInput)
+		}
+	}
+
+	config.Limit = iv.GetCaseTimeout()
+	config.MeasureMemory = false
+	times := make([]time.Duration, len(iv.sizes))
+
+	for i, c := range iv.generated {
+		d, ok := ite.MeasureSize(func() (time.Duration, bool) {
+			res := ite.CallPrepared(prepare(c), config)
+			return res.Duration, !res.TimedOut && res.Panic == nil
+		})
+
+		if !ok {
+			return &ite.Complexity{Class: ite.UnknownComplexity}
+		}
+
+		times[i] = d
+	}
+
+	return ite.EstimateComplexity(iv.sizes, times)
+}
+
+// Returns a function that prepares a call of the named solution
+// with deep copies of inputs of a test case
+func (iv *Interview2[I, I2, O]) findSolution(
+	name string,
+) (func(c *ite.TestCase[I, I2, O]) ite.Prepared[O], bool) {
+	if iv.isSingleInput {
+		f, exists := iv.solutions1.Get(name)
+		return iv.prepareFunction1(f, iv.isChecked(name)), exists
+	}
+
+	f, exists := iv.solutions2.Get(name)
+	return iv.prepareFunction2(f, iv.isChecked(name)), exists
+}
+
+// Returns true if a solution with the given name was added
+func (iv *Interview2[I, I2, O]) hasSolution(name string) bool {
+	if iv.isSingleInput {
+		return iv.solutions1.Has(name)
+	}
+
+	return iv.solutions2.Has(name)
+}
+
 // Returns true if no test cases are available
+// and no inputs are generated for the complexity estimation
 func (iv *Interview2[I, I2, O]) noCases() bool {
-	return len(iv.cases) == 0
+	return len(iv.cases) == 0 && iv.generator == nil
 }
 
 // Returns true if no solutions are available
 func (iv *Interview2[I, I2, O]) noSolutions() bool {
-	return (iv.isSingleInput && len(iv.solutions1) == 0) ||
-		(!iv.isSingleInput && len(iv.solutions2) == 0)
+	return (iv.isSingleInput && iv.solutions1.Len() == 0) ||
+		(!iv.isSingleInput && iv.solutions2.Len() == 0)
 }
 
 // Internal constructor for a new ReceiptLine
 func (iv *Interview2[I, I2, O]) newReceiptLine(
-	actual O, c *ite.TestCase[I, I2, O],
+	res ite.CallResult[O], limit time.Duration, c *ite.TestCase[I, I2, O],
+) *ite.ReceiptLine {
+	line := iv.checkResult(res, limit, c)
+	line.Modified = res.Modified
+	_, _, line.MoreInputs = iv.inputStrings(c)
+
+	if line.Modified && line.Ok && line.Mismatch == "" {
+		line.Mismatch = "=="
+	}
+
+	return line
+}
+
+// Returns the pool on which cases of one solution run
+func (iv *Interview2[I, I2, O]) casePool() *ite.Pool {
+	if iv.AreCasesConcurrent() {
+		return iv.pool()
+	}
+
+	return ite.NewPool(1)
+}
+
+// Repeats the call under varying GOMAXPROCS and stores the number
+// of different outputs and of goroutines left running into line
+func (iv *Interview2[I, I2, O]) checkRaces(
+	line *ite.ReceiptLine, first ite.CallResult[O], config ite.CallConfig,
+	prepare func(c *ite.TestCase[I, I2, O]) ite.Prepared[O],
+	c *ite.TestCase[I, I2, O],
+) {
+	check := ite.CheckRaces(first, func() ite.CallResult[O] {
+		return ite.CallPrepared(prepare(c), config)
+	}, iv.GetConcurrencyRuns(), iv.sameResult)
+	line.Distinct, line.Leaked = check.Distinct, check.Leaked
+
+	if !line.IsOk() && line.Ok && line.Mismatch == "" {
+		line.Mismatch = "=="
+	}
+}
+
+// Compares the result of a call with the test case
+func (iv *Interview2[I, I2, O]) checkResult(
+	res ite.CallResult[O], limit time.Duration, c *ite.TestCase[I, I2, O],
 ) *ite.ReceiptLine {
-	var input2 *string = nil
 	options := iv.GetOptions()
+	input, input2, _ := iv.inputStrings(c)
+	expected := c.GetExpectedString(options)
 
-	if !iv.isSingleInput {
-		val := c.GetInput2String(options)
-		input2 = &val
+	if iv.validator != nil && c.NeedsExpected() {
+		expected = "valid output"
+	}
+
+	if res.TimedOut {
+		return ite.NewTimeoutReceiptLine(limit, expected, input, input2)
+	}
+
+	if res.Panic != nil {
+		return ite.NewPanicReceiptLine(res.Panic, expected, input, input2)
+	}
+
+	if res.Err != nil {
+		line := ite.NewErrorReceiptLine(res.Err, expected, input, input2)
+		line.Ok = c.MatchesError(res.Err)
+		return line
+	}
+
+	if c.ExpectedErr != nil {
+		line := ite.NewReceiptLineImpl(
+			ite.OutputString(res.Actual, options), expected, input, input2)
+		line.Ok = false
+
+		if line.Actual == line.Expected {
+			line.Hint = "output is not an error"
+		}
+
+		return line
+	}
+
+	line := ite.NewReceiptLineImpl(
+		ite.OutputString(res.Actual, options), expected, input, input2)
+
+	if iv.validator != nil {
+		iv.validate(line, res.Actual, c)
+		return line
+	}
+
+	accepted := c.GetAccepted()
+	line.Ok = false
+
+	for i, expected := range accepted {
+		if iv.compare(line, res.Actual, *expected) {
+			line.Ok = true
+
+			if len(accepted) > 1 {
+				line.Accepted, line.Matched = len(accepted), i+1
+			}
+
+			break
+		}
+	}
+
+	if line.Ok {
+		line.Delta = nil
+	} else if line.Actual == line.Expected && len(accepted) > 0 {
+		line.Hint = ite.DistinguishValues(res.Actual, *accepted[0])
+	}
+
+	return line
+}
+
+// Returns strings representing the first input, the second input
+// or nil if there is none and the inputs following the second one.
+// Arguments of a solution with any number of inputs are split
+// into these columns.
+func (iv *Interview2[I, I2, O]) inputStrings(
+	c *ite.TestCase[I, I2, O],
+) (string, *string, []string) {
+	options := iv.GetOptions()
+
+	if columns := c.GetInputColumns(options); columns != nil {
+		switch len(columns) {
+		case 0:
+			return "", nil, nil
+		case 1:
+			return columns[0], nil, nil
+		}
+
+		return columns[0], &columns[1], columns[2:]
 	}
 
-	return ite.NewReceiptLineImpl(
-		at.AnyToStringCustom(actual, options),
-		c.GetExpectedString(options),
-		c.GetInputString(options),
-		input2,
-	)
+	if iv.isSingleInput {
+		return c.GetInputString(options), nil, nil
+	}
+
+	input2 := c.GetInput2String(options)
+	return c.GetInputString(options), &input2, nil
+}
+
+// Checks the actual output by the validator. The error message
+// is shown in place of the expected output.
+func (iv *Interview2[I, I2, O]) validate(
+	line *ite.ReceiptLine, actual O, c *ite.TestCase[I, I2, O],
+) {
+	var input2 I2
+
+	if c.Input2 != nil {
+		input2 = *c.Input2
+	}
+
+	err := iv.validator(*c.Input, input2, actual)
+	line.Ok = err == nil
+
+	if err != nil {
+		line.Expected = err.Error()
+		line.Mismatch = ":"
+	}
+}
+
+// Compares the actual output with the expected one by the comparator
+// if it is set or by deep equality with the float tolerance
+// and the order of elements.
+// The smallest difference of floats is stored in line
+// if the tolerance is set.
+func (iv *Interview2[I, I2, O]) compare(
+	line *ite.ReceiptLine, actual, expected O,
+) bool {
+	if iv.comparator != nil {
+		return iv.comparator(actual, expected)
+	}
+
+	config := ite.EqualConfig{Order: iv.order, Tolerance: iv.GetFloatTolerance()}
+	ok, delta := ite.ConfigEqual(actual, expected, config)
+
+	if !ok && config.Tolerance.IsSet() && delta > 0 &&
+		(line.Delta == nil || delta < *line.Delta) {
+		line.Delta = &delta
+	}
+
+	return ok
+}
+
+// Returns the pool on which solutions run. Runs are sequential while
+// time, memory or complexity is measured and while solutions using
+// goroutines are checked, because these depend on the whole process.
+func (iv *Interview2[I, I2, O]) pool() *ite.Pool {
+	if iv.GetTimingRuns() > 0 || iv.IsMemoryMeasured() ||
+		iv.generator != nil || iv.GetConcurrencyRuns() > 0 {
+		return ite.NewPool(1)
+	}
+
+	return iv.GetPool()
 }
 
 // Runs all solutions against all test cases
@@ -189,6 +602,26 @@ func (iv *Interview2[I, I2, O]) Print() error {
 	return iv.WriteAllSolutions(os.Stdout)
 }
 
+// Reads multiple cases without expected outputs from relative paths
+// for inputs. The expected outputs are computed by the reference solution.
+func (iv *Interview2[I, I2, O]) ReadInputs(input1RelPath, input2RelPath string) {
+	input1, err := ite.ReadData[[]I](input1RelPath)
+
+	if err != nil {
+		panic(err)
+	}
+
+	var input2 []I2
+
+	if !iv.isSingleInput {
+		if input2, err = ite.ReadData[[]I2](input2RelPath); err != nil {
+			panic(err)
+		}
+	}
+
+	iv.AddInputs(input1, input2)
+}
+
 // Reads one case from relative paths for inputs and output
 func (iv *Interview2[I, I2, O]) ReadCase(
 	input1RelPath, input2RelPath, outRelPath string,
@@ -261,47 +694,326 @@ func (iv *Interview2[I, I2, O]) ReadCasesSlice(
 	iv.AddCasesSlice(input1, input2, out, begin, end)
 }
 
-// Runs a solution for a single input problem
-// against all test cases
-func (iv *Interview2[I, I2, O]) runFunction1(f func(I) O) (r ite.Receipt) {
-	r.Lines = make([]*ite.ReceiptLine, len(iv.cases))
+// Runs calls prepared by prepare against all test cases.
+// Panics are recovered and time limits are enforced per case and
+// per solution. Once the solution limit is exhausted,
+// the remaining cases are reported as timed out without running.
+// If the timing is enabled, each case is measured over multiple runs.
+// Memory of solutions called by reflection is not measured,
+// because the allocations of reflection would be counted as well.
+func (iv *Interview2[I, I2, O]) runCases(
+	name string, prepare func(c *ite.TestCase[I, I2, O]) ite.Prepared[O],
+) ite.Receipt {
+	caseLimit, solutionLimit := iv.GetCaseTimeout(), iv.GetSolutionTimeout()
+	skipMemory := iv.IsMemoryMeasured() && iv.reflective[name]
+	config := ite.CallConfig{Limit: 0, MeasureMemory: iv.IsMemoryMeasured() && !skipMemory}
+	measure := iv.GetTimingRuns() > 0 || config.MeasureMemory
+	lines := make([]*ite.ReceiptLine, len(iv.cases))
+	start := time.Now()
+
+	iv.casePool().Run(len(iv.cases), func(i int) {
+		c, config, limit := iv.cases[i], config, caseLimit
+
+		if solutionLimit > 0 {
+			remaining := solutionLimit - time.Since(start)
+
+			if remaining <= 0 {
+				res := ite.CallResult[O]{TimedOut: true}
+				lines[i] = iv.newReceiptLine(res, solutionLimit, c)
+				return
+			}
+
+			if limit <= 0 || remaining < limit {
+				limit = remaining
+			}
+		}
+
+		config.Limit = limit
+		res := ite.CallPrepared(prepare(c), config)
+		reported := limit
+
+		if res.TimedOut && (caseLimit <= 0 || limit < caseLimit) {
+			reported = solutionLimit
+		}
+
+		lines[i] = iv.newReceiptLine(res, reported, c)
+
+		if measure && !res.TimedOut && res.Panic == nil {
+			iv.measureRuns(lines[i], res, config, prepare, c)
+		}
+
+		if iv.GetConcurrencyRuns() > 0 && !res.TimedOut {
+			iv.checkRaces(lines[i], res, config, prepare, c)
+		}
+	})
+
+	r := ite.NewReceipt(name, lines)
+	r.Description = iv.descriptions[name]
+	r.Elapsed = time.Since(start)
+	r.MemorySkipped = skipMemory
+
+	if iv.generator != nil {
+		r.Complexity = iv.estimateComplexity(prepare, config)
+	}
+
+	return r
+}
+
+// Repeats the call until the requested number of runs including
+// the first one is measured. Repetition stops early if the call panics
+// or times out. Stores the timing and average allocations into line.
+func (iv *Interview2[I, I2, O]) measureRuns(
+	line *ite.ReceiptLine, first ite.CallResult[O], config ite.CallConfig,
+	prepare func(c *ite.TestCase[I, I2, O]) ite.Prepared[O],
+	c *ite.TestCase[I, I2, O],
+) {
+	runs := iv.GetTimingRuns()
+	durations := []time.Duration{first.Duration}
+	memory := first.Memory
+
+	for len(durations) < runs {
+		res := ite.CallPrepared(prepare(c), config)
+
+		if res.TimedOut || res.Panic != nil {
+			break
+		}
+
+		durations = append(durations, res.Duration)
+
+		if memory != nil {
+			memory.Add(res.Memory)
+		}
+	}
+
+	if runs > 0 {
+		line.Timing = ite.NewTiming(durations)
+	}
+
+	if memory != nil {
+		line.Memory = memory.Average(len(durations))
+	}
+}
 
-	for i, c := range iv.cases {
-		input := dc.DeepCopy(c.Input)
-		actual := f(*input)
-		r.Lines[i] = iv.newReceiptLine(actual, c)
+// Returns true if two results of the same call are equal.
+// Outputs are compared like outputs of a solution with expected ones.
+// Panics and errors are compared by their messages.
+func (iv *Interview2[I, I2, O]) sameResult(a, b ite.CallResult[O]) bool {
+	switch {
+	case a.Panic != nil || b.Panic != nil:
+		return a.Panic != nil && b.Panic != nil &&
+			a.Panic.String() == b.Panic.String()
+	case a.Err != nil || b.Err != nil:
+		return a.Err != nil && b.Err != nil && a.Err.Error() == b.Err.Error()
 	}
 
-	r.Name = ite.GetFunctionName(f)
-	return
+	return iv.compare(ite.NewReceiptLine("", "", ""), a.Actual, b.Actual)
 }
 
-// Runs a solution for a two input problem
-// against all test cases
-func (iv *Interview2[I, I2, O]) runFunction2(f func(I, I2) O) (r ite.Receipt) {
-	r.Lines = make([]*ite.ReceiptLine, len(iv.cases))
+// Sets a function that decides whether the actual output
+// of a solution matches the expected one.
+// Nil restores the default typed deep equality.
+func (iv *Interview2[I, I2, O]) SetComparator(
+	comparator func(actual, expected O) bool,
+) {
+	iv.comparator = comparator
+}
 
-	for i, c := range iv.cases {
-		input, input2 := dc.DeepCopy(c.Input), dc.DeepCopy(c.Input2)
-		actual := f(*input, *input2)
-		r.Lines[i] = iv.newReceiptLine(actual, c)
+// Registers a generator of inputs of size n. Every solution is then run
+// on inputs of all given sizes and its time complexity is estimated
+// and shown next to its name. At least three sizes are required.
+func (iv *Interview2[I, I2, O]) SetGenerator(
+	generator func(n int) (I, I2), sizes ...int,
+) {
+	if generator != nil && len(sizes) < ite.MinComplexitySizes {
+		panic(fmt.Errorf(
+			"At least %d sizes are required, found %d",
+			ite.MinComplexitySizes, len(sizes)))
 	}
 
-	r.Name = ite.GetFunctionName(f)
-	return
+	iv.generated = nil
+	iv.generator = generator
+	iv.sizes = sizes
+}
+
+// Computes expected outputs of cases added without them
+// by running the reference solution.
+// Nothing is computed if the validator is set.
+func (iv *Interview2[I, I2, O]) resolveExpected() error {
+	var prepare func(c *ite.TestCase[I, I2, O]) ite.Prepared[O]
+	options := iv.GetOptions()
+
+	if iv.validator != nil {
+		return nil
+	}
+
+	for _, c := range iv.cases {
+		if !c.NeedsExpected() {
+			continue
+		}
+
+		if prepare == nil {
+			if iv.reference == "" {
+				return fmt.Errorf(
+					"reference solution is required for cases without expected output")
+			}
+
+			var exists bool
+
+			if prepare, exists = iv.findSolution(iv.reference); !exists {
+				return fmt.Errorf("reference solution %s not found", iv.reference)
+			}
+		}
+
+		config := ite.CallConfig{Limit: iv.GetCaseTimeout(), MeasureMemory: false}
+		res := ite.CallPrepared(prepare(c), config)
+
+		if res.TimedOut {
+			return fmt.Errorf("reference solution %s timed out on %s",
+				iv.reference, c.GetInputString(options))
+		}
+
+		if res.Panic != nil {
+			return fmt.Errorf("reference solution %s failed on %s with %s",
+				iv.reference, c.GetInputString(options), res.Panic)
+		}
+
+		if res.Err != nil {
+			c.SetExpectedError(res.Err)
+		} else {
+			c.SetExpected(&res.Actual)
+		}
+	}
+
+	return nil
+}
+
+// Returns a function that prepares a call of a single input solution.
+// If checked is true, the copied input is compared with the original
+// after the call.
+func (iv *Interview2[I, I2, O]) prepareFunction1(
+	f func(I) (O, error), checked bool,
+) func(c *ite.TestCase[I, I2, O]) ite.Prepared[O] {
+	return func(c *ite.TestCase[I, I2, O]) ite.Prepared[O] {
+		input := ite.DeepCopy(c.Input)
+		res := ite.Prepared[O]{
+			Call: func() (O, error) {
+				return f(*input)
+			},
+			Modified: nil,
+		}
+
+		if checked {
+			res.Modified = func() bool {
+				return !isEqual(*input, *c.Input)
+			}
+		}
+
+		return res
+	}
+}
+
+// Returns a function that prepares a call of a two input solution.
+// If checked is true, the copied inputs are compared with the originals
+// after the call.
+func (iv *Interview2[I, I2, O]) prepareFunction2(
+	f func(I, I2) (O, error), checked bool,
+) func(c *ite.TestCase[I, I2, O]) ite.Prepared[O] {
+	return func(c *ite.TestCase[I, I2, O]) ite.Prepared[O] {
+		input, input2 := ite.DeepCopy(c.Input), ite.DeepCopy(c.Input2)
+		res := ite.Prepared[O]{
+			Call: func() (O, error) {
+				return f(*input, *input2)
+			},
+			Modified: nil,
+		}
+
+		if checked {
+			res.Modified = func() bool {
+				return !isEqual(*input, *c.Input) || !isEqual(*input2, *c.Input2)
+			}
+		}
+
+		return res
+	}
+}
+
+// Returns true if inputs of the named solution
+// are checked for modifications
+func (iv *Interview2[I, I2, O]) isChecked(name string) bool {
+	return iv.IsInputMutationDetected() && !iv.inPlace[name]
+}
+
+// Runs a solution for a single input problem against all test cases
+func (iv *Interview2[I, I2, O]) runFunction1(
+	name string, f func(I) (O, error),
+) ite.Receipt {
+	return iv.runCases(name, iv.prepareFunction1(f, iv.isChecked(name)))
+}
+
+// Runs a solution for a two input problem against all test cases
+func (iv *Interview2[I, I2, O]) runFunction2(
+	name string, f func(I, I2) (O, error),
+) ite.Receipt {
+	return iv.runCases(name, iv.prepareFunction2(f, iv.isChecked(name)))
+}
+
+// Panics if the output type differs from the type of the input,
+// because in-place solutions return their modified input
+func checkInPlace[I any, O any]() {
+	if it, ot := r.TypeFor[I](), r.TypeFor[O](); it != ot {
+		panic(fmt.Errorf(
+			"in-place solution requires output type %v, found %v", it, ot))
+	}
+}
+
+// Returns true if two inputs are equal without any tolerance
+func isEqual[T any](a, b T) bool {
+	ok, _ := ite.ConfigEqual(a, b, ite.EqualConfig{
+		Order: ite.Ordered, Tolerance: ite.Tolerance{Abs: 0, Rel: 0},
+	})
+	return ok
+}
+
+// Returns a function that calls the solution s returning multiple values
+// by reflection. Panics if s doesn't take the inputs or return
+// the fields of the output.
+func wrapTuple[O any](s any, inputs ...r.Type) func(args ...r.Value) (O, error) {
+	f, err := ite.WrapFunction[O](s, inputs)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return func(args ...r.Value) (O, error) {
+		return f(args)
+	}
+}
+
+// Wraps a single input solution so that it returns no error
+func noError1[I any, O any](f func(I) O) func(I) (O, error) {
+	return func(input I) (O, error) {
+		return f(input), nil
+	}
+}
+
+// Wraps a two input solution so that it returns no error
+func noError2[I any, I2 any, O any](f func(I, I2) O) func(I, I2) (O, error) {
+	return func(input I, input2 I2) (O, error) {
+		return f(input, input2), nil
+	}
 }
 
 // Runs one solution function against all test cases.
 // If function cannot be found, an error is returned.
 func (iv *Interview2[I, I2, O]) RunSolution(name string) (ite.Receipt, error) {
 	var exists bool
-	var fn1 func(I) O
-	var fn2 func(I, I2) O
+	var fn1 func(I) (O, error)
+	var fn2 func(I, I2) (O, error)
 
 	if iv.isSingleInput {
-		fn1, exists = iv.solutions1[name]
+		fn1, exists = iv.solutions1.Get(name)
 	} else {
-		fn2, exists = iv.solutions2[name]
+		fn2, exists = iv.solutions2.Get(name)
 	}
 
 	if !exists {
@@ -309,20 +1021,59 @@ func (iv *Interview2[I, I2, O]) RunSolution(name string) (ite.Receipt, error) {
 		return res, fmt.Errorf("solution %s not found", name)
 	}
 
+	if err := iv.resolveExpected(); err != nil {
+		return ite.Receipt{Lines: nil, Name: ""}, err
+	}
+
 	if iv.isSingleInput {
-		return iv.runFunction1(fn1), nil
+		return iv.runFunction1(name, fn1), nil
 	}
 
-	return iv.runFunction2(fn2), nil
+	return iv.runFunction2(name, fn2), nil
 }
 
-// Runs all solutions against all test cases
+// Runs all solutions against all test cases.
+// Panics if expected outputs cannot be computed by the reference solution.
 func (iv *Interview2[I, I2, O]) RunAllSolutions() ite.ReceiptSlice {
+	if err := iv.resolveExpected(); err != nil {
+		panic(err)
+	}
+
 	if iv.isSingleInput {
-		return ite.ExecuteSolutions(iv.solutions1, iv.runFunction1)
+		return ite.ExecuteSolutions(
+			iv.solutions1, iv.runFunction1, iv.GetSolutionOrder(), iv.pool())
+	}
+
+	return ite.ExecuteSolutions(
+		iv.solutions2, iv.runFunction2, iv.GetSolutionOrder(), iv.pool())
+}
+
+// Marks a registered solution as the reference. Expected outputs
+// of cases added without them are computed by this solution
+// and all other solutions are compared against them.
+// If the solution cannot be found, an error is returned.
+func (iv *Interview2[I, I2, O]) SetReference(name string) error {
+	if !iv.hasSolution(name) {
+		return fmt.Errorf("solution %s not found", name)
+	}
+
+	iv.reference = name
+
+	for _, c := range iv.cases {
+		c.ResetExpected()
 	}
 
-	return ite.ExecuteSolutions(iv.solutions2, iv.runFunction2)
+	return nil
+}
+
+// Sets a validator that checks the output of a solution instead
+// of comparing it with the expected output. An error returned
+// by the validator marks the case as failed and its message is shown.
+// Cases can then be added with inputs only. Nil removes the validator.
+func (iv *Interview2[I, I2, O]) SetValidator(
+	validator func(input I, input2 I2, actual O) error,
+) {
+	iv.validator = validator
 }
 
 // Runs all solutions against all test cases
@@ -334,6 +1085,10 @@ func (iv *Interview2[I, I2, O]) WriteAllSolutions(w io.Writer) error {
 		_, err = w.Write([]byte("No test cases provided by the user!"))
 	} else if iv.noSolutions() {
 		_, err = w.Write([]byte("No solution functions provided by the user!"))
+	} else if resolveErr := iv.resolveExpected(); resolveErr != nil {
+		if _, err = w.Write([]byte(resolveErr.Error())); err == nil {
+			err = resolveErr
+		}
 	} else {
 		var builder strings.Builder
 		slice := iv.RunAllSolutions()
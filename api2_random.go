@@ -0,0 +1,242 @@
+package gointerview
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path"
+	r "reflect"
+
+	ite "github.com/Matej-Chmel/go-interview/internal"
+)
+
+// Maximum size passed to the generator of random inputs
+const maxRandomSize = 100
+
+// Runs all solutions except the reference against random inputs
+// and prints the minimal failing input of each solution
+// to the standard output
+func (iv *Interview2[I, I2, O]) PrintRandomTest(runs int, seed uint64) error {
+	slice := iv.RandomTest(runs, seed)
+	_, err := os.Stdout.WriteString(slice.String())
+	return err
+}
+
+// Runs all solutions except the reference against random inputs.
+// Each output is checked by the property if it is set, compared
+// against the output of the reference solution if it is set
+// or checked by the validator. If a solution fails,
+// its failing input is shrunk to a minimal one that still fails.
+// The same seed always produces the same inputs.
+// Panics if none of the property, the reference solution
+// and the validator is set.
+func (iv *Interview2[I, I2, O]) RandomTest(
+	runs int, seed uint64,
+) ite.RandomReceiptSlice {
+	var reference func(c *ite.TestCase[I, I2, O]) ite.Prepared[O]
+
+	if iv.property == nil && (iv.reference != "" || iv.validator == nil) {
+		var exists bool
+
+		if iv.reference == "" {
+			panic(errors.New("random testing requires a reference solution, " +
+				"a property or a validator"))
+		}
+
+		if reference, exists = iv.findSolution(iv.reference); !exists {
+			panic(fmt.Errorf("reference solution %s not found", iv.reference))
+		}
+	}
+
+	res := ite.RandomReceiptSlice{Receipts: make([]ite.RandomReceipt, 0)}
+
+	for _, name := range iv.solutionNames() {
+		if name == iv.reference && iv.property == nil {
+			continue
+		}
+
+		prepare, _ := iv.findSolution(name)
+		res.Receipts = append(res.Receipts,
+			iv.randomTestSolution(name, prepare, reference, runs, seed))
+	}
+
+	return res
+}
+
+// Sets a directory relative to the main Go file where minimal failing
+// inputs found by random testing are saved. Files can be read back
+// by ReadCase. Empty string disables saving.
+func (iv *Interview2[I, I2, O]) SaveRandomCases(relDir string) {
+	iv.randomCaseDir = relDir
+}
+
+// Sets a property that every output of a solution must satisfy.
+// Random testing then checks the property instead of comparing outputs
+// against the reference solution. Nil removes the property.
+func (iv *Interview2[I, I2, O]) SetProperty(
+	property func(input I, input2 I2, actual O) bool,
+) {
+	iv.property = property
+}
+
+// Sets a generator of random inputs. The size grows from 1 to 100
+// during the test. If no generator is set, inputs are generated
+// by reflection.
+func (iv *Interview2[I, I2, O]) SetRandomGenerator(
+	generator func(rng *rand.Rand, size int) (I, I2),
+) {
+	iv.randomGenerator = generator
+}
+
+// Runs one solution on one input. Returns the test case, a receipt line
+// and true if the solution failed. If the reference solution fails,
+// the input is considered invalid and not failing. Without the reference,
+// the output is checked by the property or by the validator.
+func (iv *Interview2[I, I2, O]) checkRandom(
+	prepare, reference func(c *ite.TestCase[I, I2, O]) ite.Prepared[O],
+	input I, input2 I2,
+) (*ite.TestCase[I, I2, O], *ite.ReceiptLine, bool) {
+	config := ite.CallConfig{Limit: iv.GetCaseTimeout(), MeasureMemory: false}
+	c := ite.NewInputCase[I, I2, O](&input, &input2, iv.isSingleInput)
+	byProperty := reference == nil && iv.property != nil
+
+	if reference != nil {
+		expected := ite.CallPrepared(reference(c), config)
+
+		if expected.TimedOut || expected.Panic != nil {
+			return c, nil, false
+		}
+
+		if expected.Err != nil {
+			c.SetExpectedError(expected.Err)
+		} else {
+			c.SetExpected(&expected.Actual)
+		}
+	} else if byProperty {
+		var zero O
+		c.SetExpected(&zero)
+	}
+
+	res := ite.CallPrepared(prepare(c), config)
+
+	if byProperty && !res.TimedOut && res.Panic == nil && res.Err == nil &&
+		iv.property(input, input2, res.Actual) {
+		return c, nil, false
+	}
+
+	line := iv.newReceiptLine(res, config.Limit, c)
+
+	if byProperty {
+		line.Accepted, line.Delta, line.Matched = 0, nil, 0
+		line.Expected = "property violated"
+		line.Mismatch = ":"
+		line.Ok = false
+	}
+
+	return c, line, byProperty || !line.IsOk()
+}
+
+// Generates inputs of given size by the generator or by reflection
+func (iv *Interview2[I, I2, O]) randomInputs(rng *rand.Rand, size int) (I, I2) {
+	if iv.randomGenerator != nil {
+		return iv.randomGenerator(rng, size)
+	}
+
+	var input2 I2
+
+	if !iv.isSingleInput {
+		input2 = ite.RandomValue[I2](rng, size)
+	}
+
+	return ite.RandomValue[I](rng, size), input2
+}
+
+// Runs one solution against random inputs until it fails
+// and then shrinks the failing input
+func (iv *Interview2[I, I2, O]) randomTestSolution(
+	name string, prepare, reference func(c *ite.TestCase[I, I2, O]) ite.Prepared[O],
+	runs int, seed uint64,
+) ite.RandomReceipt {
+	res := ite.RandomReceipt{Name: name, Runs: runs, Seed: seed}
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	for i := 0; i < runs; i++ {
+		size := 1 + i*maxRandomSize/max(runs, 1)
+		input, input2 := iv.randomInputs(rng, size)
+
+		if _, _, failed := iv.checkRandom(prepare, reference, input, input2); !failed {
+			continue
+		}
+
+		values := []r.Value{r.ValueOf(&input).Elem()}
+
+		if !iv.isSingleInput {
+			values = append(values, r.ValueOf(&input2).Elem())
+		}
+
+		values, res.Shrinks = ite.Shrink(values, func(values []r.Value) bool {
+			i1, i2 := iv.fromValues(values, input2)
+			_, _, failed := iv.checkRandom(prepare, reference, i1, i2)
+			return failed
+		})
+
+		input, input2 = iv.fromValues(values, input2)
+		c, line, _ := iv.checkRandom(prepare, reference, input, input2)
+		res.Failure, res.FailedAt = line, i+1
+		res.Inputs = []any{input}
+
+		if !iv.isSingleInput {
+			res.Inputs = append(res.Inputs, input2)
+		}
+
+		if iv.randomCaseDir != "" {
+			iv.saveRandomCase(&res, c, reference != nil)
+		}
+
+		break
+	}
+
+	return res
+}
+
+// Converts shrunk values back to inputs.
+// The second input is kept for single input problems.
+func (iv *Interview2[I, I2, O]) fromValues(values []r.Value, input2 I2) (I, I2) {
+	if len(values) > 1 {
+		input2 = ite.FromValue[I2](values[1])
+	}
+
+	return ite.FromValue[I](values[0]), input2
+}
+
+// Saves inputs of the minimal failing case and the expected output
+// if it was computed by the reference solution.
+// An expected error returned by the reference solution is not saved.
+func (iv *Interview2[I, I2, O]) saveRandomCase(
+	res *ite.RandomReceipt, c *ite.TestCase[I, I2, O], withExpected bool,
+) {
+	data := []any{*c.Input}
+	suffixes := []string{"_in.txt"}
+
+	if !iv.isSingleInput {
+		data = append(data, *c.Input2)
+		suffixes = append(suffixes, "_in2.txt")
+	}
+
+	if withExpected && c.Expected != nil {
+		data = append(data, *c.Expected)
+		suffixes = append(suffixes, "_out.txt")
+	}
+
+	for i, d := range data {
+		relPath := path.Join(iv.randomCaseDir, res.Name+suffixes[i])
+
+		if err := ite.WriteData(relPath, d); err != nil {
+			res.SaveError = err
+			return
+		}
+
+		res.Saved = append(res.Saved, relPath)
+	}
+}
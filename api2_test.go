@@ -1,6 +1,8 @@
 package gointerview_test
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -90,6 +92,22 @@ func matrixMult(a, b [][]int8) [][]int8 {
 	return res
 }
 
+func twoSumFirst(nums []int, target int) []int {
+	return []int{0, 1}
+}
+
+func twoSumQuadratic(nums []int, target int) []int {
+	for i := range nums {
+		for j := i + 1; j < len(nums); j++ {
+			if nums[i]+nums[j] == target {
+				return []int{j, i}
+			}
+		}
+	}
+
+	return nil
+}
+
 func unexportedNestedProduct(a, b unexportedNested2) unexportedNested2 {
 	return unexportedNested2{
 		unexported2: unexported2{a: a.a * b.a, B: a.B * b.B},
@@ -104,6 +122,16 @@ func unexportedProduct(a, b unexported2) unexported2 {
 	}
 }
 
+func Fuzz2Unexported(f *testing.F) {
+	iv := goi.NewInterview2[unexported2, unexported2, unexported2]()
+	iv.AddSolution(unexportedProduct)
+	iv.AddCase(unexported2{a: 2, B: 3}, unexported2{a: 4, B: 5},
+		unexported2{a: 8, B: 15})
+	iv.Fuzz(f, func(a, b unexported2) unexported2 {
+		return unexported2{a: a.a * b.a, B: a.B * b.B}
+	})
+}
+
 func Test2Exported(ot *testing.T) {
 	t := ite.NewTester(ot)
 	iv := goi.NewInterview2[ite.Exported, ite.Exported, ite.Exported]()
@@ -245,6 +273,74 @@ func Test2Swap(ot *testing.T) {
 	})
 }
 
+func Test2Tolerance(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview2[float64, float64, float64]()
+	iv.AddCase(1.1, 2.2, 1.1+2.2)
+	iv.AddCase(5.24, 0.0, 5.24)
+	iv.AddSolutions(addOne, addTwo)
+	iv.SetFloatTolerance(1e-9, 0)
+
+	t.CheckStrings(1, iv.AllSolutionsToString(), strings.Join([]string{
+		"addOne",
+		"======",
+		"(OK) 1.1, 2.2 -> 3.3",
+		"(OK) 5.24, 0.0 -> 5.24",
+		"",
+		"addTwo",
+		"======",
+		"(  ) 1.1, 2.2 -> 2.42 != 3.3  [delta 0.88]",
+		"(  ) 5.24, 0.0 -> 0.0 != 5.24  [delta 5.24]",
+	}, "\n"))
+
+	nested := goi.NewInterview2[
+		unexportedNested2, unexportedNested2, unexportedNested2]()
+	nested.AddCase(
+		unexportedNested2{unexported2: unexported2{a: 0.1, B: 2}, C: true},
+		unexportedNested2{unexported2: unexported2{a: 3, B: 1}, C: true},
+		unexportedNested2{unexported2: unexported2{a: 0.3001, B: 2}, C: true})
+	nested.AddSolution(unexportedNestedProduct)
+	nested.SetFloatTolerance(0, 1e-3)
+
+	rec, err := nested.RunSolution("unexportedNestedProduct")
+	t.CheckName(err, rec.Name, "unexportedNestedProduct")
+
+	if rec.Passed != 1 {
+		t.Throw(1, "Nested float not compared with tolerance")
+	}
+}
+
+func Test2Validator(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview2[[]int, int, []int]()
+	iv.AddInput([]int{1, 2, 3}, 5)
+	iv.AddInput([]int{4, 4}, 8)
+	iv.AddSolutions(twoSumFirst, twoSumQuadratic)
+	iv.SetValidator(func(nums []int, target int, actual []int) error {
+		if len(actual) != 2 || actual[0] == actual[1] {
+			return errors.New("two distinct indices expected")
+		}
+
+		if sum := nums[actual[0]] + nums[actual[1]]; sum != target {
+			return fmt.Errorf("sum is %d", sum)
+		}
+
+		return nil
+	})
+
+	t.CheckStrings(1, iv.AllSolutionsToString(), strings.Join([]string{
+		"twoSumFirst",
+		"===========",
+		"(  ) [1 2 3], 5 -> [0 1] : sum is 3",
+		"(OK) [4 4], 8 -> [0 1]",
+		"",
+		"twoSumQuadratic",
+		"===============",
+		"(OK) [1 2 3], 5 -> [2 1]",
+		"(OK) [4 4], 8 -> [1 0]",
+	}, "\n"))
+}
+
 func Test2Unexported(ot *testing.T) {
 	t := ite.NewTester(ot)
 	iv := goi.NewInterview2[unexported2, unexported2, unexported2]()
@@ -0,0 +1,119 @@
+package gointerview
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	ite "github.com/Matej-Chmel/go-interview/internal"
+)
+
+// Runs a sub-benchmark for every pair of a solution and a test case
+// named after the solution and the case index. Inputs are copied
+// outside of the timed region and allocations are reported.
+// A sub-benchmark in which the solution panics fails
+// without stopping the others.
+func (iv *Interview2[I, I2, O]) RunBenchmarks(b *testing.B) {
+	b.Helper()
+
+	for _, name := range iv.solutionNames() {
+		prepare, _ := iv.findSolution(name)
+
+		b.Run(name, func(b *testing.B) {
+			for i, c := range iv.cases {
+				b.Run(strconv.Itoa(i), func(b *testing.B) {
+					b.ReportAllocs()
+
+					for j := 0; j < b.N; j++ {
+						b.StopTimer()
+						call := prepare(c)
+						b.StartTimer()
+
+						if _, p, _ := ite.SafeCall(call.Call); p != nil {
+							b.Fatalf("%s\n%s", p, strings.Join(p.Stack, "\n"))
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+// Registers a fuzz target that runs all solutions on inputs decoded from
+// the fuzzing data and compares their outputs with the reference.
+// The corpus is seeded from the inputs of added test cases.
+// Inputs on which the reference panics or times out are skipped.
+func (iv *Interview2[I, I2, O]) Fuzz(f *testing.F, reference func(I, I2) O) {
+	f.Helper()
+	iv.fuzzImpl(f, iv.prepareFunction2(noError2(reference), false))
+}
+
+// Implementation of Fuzz shared by single and two input problems
+func (iv *Interview2[I, I2, O]) fuzzImpl(
+	f *testing.F, reference func(c *ite.TestCase[I, I2, O]) ite.Prepared[O],
+) {
+	for _, c := range iv.cases {
+		if iv.isSingleInput {
+			f.Add(ite.EncodeValues(*c.Input))
+		} else {
+			f.Add(ite.EncodeValues(*c.Input, *c.Input2))
+		}
+	}
+
+	names := iv.solutionNames()
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		input, input2 := ite.DecodeInputs[I, I2](data, iv.isSingleInput)
+
+		for _, name := range names {
+			prepare, _ := iv.findSolution(name)
+			_, line, failed := iv.checkRandom(prepare, reference, input, input2)
+
+			if failed {
+				t.Errorf("%s\n%s", name, line)
+			}
+		}
+	})
+}
+
+// Runs every solution as a subtest named after the solution
+// with one subtest per test case named after the case index.
+// Mismatches, panics and timeouts are reported with the receipt line.
+func (iv *Interview2[I, I2, O]) RunTests(t *testing.T) {
+	t.Helper()
+
+	if err := iv.resolveExpected(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range iv.solutionNames() {
+		prepare, _ := iv.findSolution(name)
+
+		t.Run(name, func(t *testing.T) {
+			for i, c := range iv.cases {
+				t.Run(strconv.Itoa(i), func(t *testing.T) {
+					config := ite.CallConfig{
+						Limit: iv.GetCaseTimeout(), MeasureMemory: false,
+					}
+					res := ite.CallPrepared(prepare(c), config)
+
+					if line := iv.newReceiptLine(res, config.Limit, c); !line.IsOk() {
+						t.Error("\n" + line.String())
+					}
+				})
+			}
+		})
+	}
+}
+
+// Returns names of all solutions in alphabetical order
+// or in the order in which they were added if another order is set
+func (iv *Interview2[I, I2, O]) solutionNames() []string {
+	alphabetical := iv.GetSolutionOrder() == ite.Alphabetical
+
+	if iv.isSingleInput {
+		return iv.solutions1.Names(alphabetical)
+	}
+
+	return iv.solutions2.Names(alphabetical)
+}
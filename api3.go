@@ -0,0 +1,188 @@
+package gointerview
+
+import (
+	"io"
+	r "reflect"
+	"testing"
+
+	ite "github.com/Matej-Chmel/go-interview/internal"
+)
+
+// Class for three input problems.
+// Delegates all implementation to InterviewN
+// with the input types fixed in advance.
+type Interview3[I any, I2 any, I3 any, O any] struct {
+	*ite.EmbeddedOptions
+	iv InterviewN[O]
+}
+
+// Constructs an Interview3 object
+func NewInterview3[I any, I2 any, I3 any, O any]() Interview3[I, I2, I3, O] {
+	iv := NewInterviewN[O]()
+	iv.inputTypes = []r.Type{r.TypeFor[I](), r.TypeFor[I2](), r.TypeFor[I3]()}
+
+	return Interview3[I, I2, I3, O]{
+		EmbeddedOptions: iv.EmbeddedOptions,
+		iv:              iv,
+	}
+}
+
+// Adds one test case
+func (iv *Interview3[I, I2, I3, O]) AddCase(
+	input I, input2 I2, input3 I3, expected O,
+) {
+	iv.iv.AddCase(input, input2, input3, expected)
+}
+
+// Adds one solution function that returns an error for invalid input
+func (iv *Interview3[I, I2, I3, O]) AddErrorSolution(
+	s func(I, I2, I3) (O, error),
+) {
+	iv.iv.AddSolution(s)
+}
+
+// Adds multiple solution functions that return an error for invalid input
+func (iv *Interview3[I, I2, I3, O]) AddErrorSolutions(
+	s ...func(I, I2, I3) (O, error),
+) {
+	for _, f := range s {
+		iv.AddErrorSolution(f)
+	}
+}
+
+// Adds one test case without an expected output.
+// The expected output is computed by the reference solution.
+func (iv *Interview3[I, I2, I3, O]) AddInput(input I, input2 I2, input3 I3) {
+	iv.iv.AddInput(input, input2, input3)
+}
+
+// Adds one solution function named after the function.
+// Panics if a solution with the same name was already added.
+func (iv *Interview3[I, I2, I3, O]) AddSolution(s func(I, I2, I3) O) {
+	iv.iv.AddSolution(s)
+}
+
+// Adds one solution function under the given name.
+// Panics if a solution with the same name was already added.
+func (iv *Interview3[I, I2, I3, O]) AddNamedSolution(
+	name string, s func(I, I2, I3) O,
+) {
+	iv.iv.AddNamedSolution(name, s)
+}
+
+// Adds multiple solution functions
+func (iv *Interview3[I, I2, I3, O]) AddSolutions(s ...func(I, I2, I3) O) {
+	for _, f := range s {
+		iv.AddSolution(f)
+	}
+}
+
+// Adds one solution function returning multiple values.
+// The values are collected into the output, which must be a struct
+// such as Pair or Triple with fields of the returned types in order.
+// The values can be followed by an error.
+// Panics if s doesn't have such a signature.
+func (iv *Interview3[I, I2, I3, O]) AddTupleSolution(s any) {
+	iv.iv.AddSolution(s)
+}
+
+// Adds multiple solution functions returning multiple values
+func (iv *Interview3[I, I2, I3, O]) AddTupleSolutions(s ...any) {
+	for _, f := range s {
+		iv.AddTupleSolution(f)
+	}
+}
+
+// Runs all solutions against all test cases
+// and compiles the output into a single string
+func (iv *Interview3[I, I2, I3, O]) AllSolutionsToString() string {
+	return iv.iv.AllSolutionsToString()
+}
+
+// Sets a short description of the named solution
+// that is shown under its name in the output.
+// If the solution cannot be found, an error is returned.
+func (iv *Interview3[I, I2, I3, O]) DescribeSolution(name, description string) error {
+	return iv.iv.DescribeSolution(name, description)
+}
+
+// Ignores the order of elements of an output slice or array.
+// For map outputs, the order of elements of slices stored
+// as map values is ignored. Nested slices keep their order.
+func (iv *Interview3[I, I2, I3, O]) IgnoreOrder() {
+	iv.iv.IgnoreOrder()
+}
+
+// Ignores the order of elements at every level of nested
+// slices and arrays in the output
+func (iv *Interview3[I, I2, I3, O]) IgnoreNestedOrder() {
+	iv.iv.IgnoreNestedOrder()
+}
+
+// Runs all solutions against all test cases
+// and prints the output to the standard output
+func (iv *Interview3[I, I2, I3, O]) Print() error {
+	return iv.iv.Print()
+}
+
+// Reads one case from relative paths for inputs and output
+func (iv *Interview3[I, I2, I3, O]) ReadCase(
+	input1RelPath, input2RelPath, input3RelPath, outRelPath string,
+) {
+	iv.iv.ReadCase(input1RelPath, input2RelPath, input3RelPath, outRelPath)
+}
+
+// Reads multiple cases from relative paths for inputs and output
+func (iv *Interview3[I, I2, I3, O]) ReadCases(
+	input1RelPath, input2RelPath, input3RelPath, outRelPath string,
+) {
+	iv.iv.ReadCases(input1RelPath, input2RelPath, input3RelPath, outRelPath)
+}
+
+// Runs one solution function against all test cases.
+// If function cannot be found, an error is returned.
+func (iv *Interview3[I, I2, I3, O]) RunSolution(name string) (ite.Receipt, error) {
+	return iv.iv.RunSolution(name)
+}
+
+// Runs a sub-benchmark for every pair of a solution and a test case
+// named after the solution and the case index
+func (iv *Interview3[I, I2, I3, O]) RunBenchmarks(b *testing.B) {
+	b.Helper()
+	iv.iv.RunBenchmarks(b)
+}
+
+// Runs all solutions against all test cases
+func (iv *Interview3[I, I2, I3, O]) RunAllSolutions() ite.ReceiptSlice {
+	return iv.iv.RunAllSolutions()
+}
+
+// Runs every solution as a subtest named after the solution
+// with one subtest per test case named after the case index
+func (iv *Interview3[I, I2, I3, O]) RunTests(t *testing.T) {
+	t.Helper()
+	iv.iv.RunTests(t)
+}
+
+// Sets a function that decides whether the actual output
+// of a solution matches the expected one.
+// Nil restores the default typed deep equality.
+func (iv *Interview3[I, I2, I3, O]) SetComparator(
+	comparator func(actual, expected O) bool,
+) {
+	iv.iv.SetComparator(comparator)
+}
+
+// Marks a registered solution as the reference. Expected outputs
+// of cases added without them are computed by this solution
+// and all other solutions are compared against them.
+// If the solution cannot be found, an error is returned.
+func (iv *Interview3[I, I2, I3, O]) SetReference(name string) error {
+	return iv.iv.SetReference(name)
+}
+
+// Runs all solutions against all test cases
+// and writes the results into a writer w
+func (iv *Interview3[I, I2, I3, O]) WriteAllSolutions(w io.Writer) error {
+	return iv.iv.WriteAllSolutions(w)
+}
@@ -0,0 +1,91 @@
+package gointerview_test
+
+import (
+	"errors"
+	"testing"
+
+	goi "github.com/Matej-Chmel/go-interview"
+	ite "github.com/Matej-Chmel/go-interview/internal"
+)
+
+func countInRange(nums []int, lo, hi int) (res int) {
+	for _, v := range nums {
+		if lo <= v && v <= hi {
+			res++
+		}
+	}
+
+	return
+}
+
+func countBelow(nums []int, lo, hi int) (res int) {
+	for _, v := range nums {
+		if lo <= v && v < hi {
+			res++
+		}
+	}
+
+	return
+}
+
+func clampSum(a, b int, lo, hi float64) (float64, error) {
+	if lo > hi {
+		return 0, errors.New("empty range")
+	}
+
+	return max(lo, min(hi, float64(a+b))), nil
+}
+
+func Test3CountInRange(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview3[[]int, int, int, int]()
+	iv.AddSolutions(countInRange, countBelow)
+	iv.ReadCases(
+		"test_data/countInRange_in.txt",
+		"test_data/countInRange_in2.txt",
+		"test_data/countInRange_in3.txt",
+		"test_data/countInRange_out.txt")
+
+	rec, err := iv.RunSolution("countBelow")
+	t.CheckName(err, rec.Name, "countBelow")
+	t.CheckStrings(1, rec.Lines[0].String(), "(  ) [1 5 3 8 2], 2, 5 -> 2 != 3")
+	t.CheckStrings(1, rec.Lines[1].String(), "(  ) [4 4 4], 4, 4 -> 0 != 3")
+}
+
+func TestNClampSum(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterviewN[float64]()
+	iv.AddSolution(clampSum)
+	iv.AddCase(1, 2, 0, 10, 3)
+	iv.AddCase(5, 6, 0, 10, 10)
+	iv.AddCase(1, 1, 5, 0, 0)
+
+	rec, err := iv.RunSolution("clampSum")
+	t.CheckName(err, rec.Name, "clampSum")
+	t.CheckStrings(1, rec.Lines[0].String(), "(OK) 1, 2, 0.0, 10.0 -> 3.0")
+	t.CheckStrings(1, rec.Lines[2].String(),
+		"(ERR) 1, 1, 5.0, 0.0 -> error: empty range != 0.0")
+
+	defer func() {
+		if recover() == nil {
+			t.Throw(1, "expected a panic for a mismatched signature")
+		}
+	}()
+
+	iv.AddSolution(countInRange)
+}
+
+func TestNMemory(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterviewN[float64]()
+	iv.AddNamedSolution("clamp", clampSum)
+	iv.AddCase(1, 2, 0, 10, 3)
+	iv.MeasureMemory()
+
+	rec, err := iv.RunSolution("clamp")
+	t.CheckName(err, rec.Name, "clamp")
+
+	if rec.Memory != nil || !rec.MemorySkipped {
+		t.Throw(1, "clamp measured %v", rec.Memory)
+	}
+}
@@ -1,13 +1,36 @@
 package gointerview_test
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	goi "github.com/Matej-Chmel/go-interview"
 	ite "github.com/Matej-Chmel/go-interview/internal"
 )
 
+var errNegative = errors.New("negative number")
+
+type scaler struct {
+	factor int
+}
+
+type treeNode struct {
+	Val   int
+	Left  *treeNode
+	Right *treeNode
+}
+
 type unexported struct {
 	a int
 	B int
@@ -26,6 +49,18 @@ func badFactorial(n int) (r int) {
 	return
 }
 
+func badTreeSize(root *treeNode) int {
+	if root == nil {
+		return 0
+	}
+
+	return 1 + badTreeSize(root.Left)
+}
+
+func badMinMax(nums []int) (int, int) {
+	return nums[0], nums[len(nums)-1]
+}
+
 func badSort(nums []int) []int {
 	sort.Slice(nums, func(i, j int) bool {
 		return nums[i] < nums[j]
@@ -39,6 +74,31 @@ func bytes(s []byte) []byte {
 	return s
 }
 
+func checkedInc(n int) (int, error) {
+	if n < 0 {
+		return 0, errNegative
+	}
+
+	return n + 1, nil
+}
+
+func countPairsFormula(nums []int) int {
+	n := len(nums)
+	return n * (n - 1) / 2
+}
+
+func countPairsQuadratic(nums []int) (r int) {
+	for i := range nums {
+		for j := i + 1; j < len(nums); j++ {
+			if nums[i] <= nums[j] {
+				r++
+			}
+		}
+	}
+
+	return
+}
+
 func exportedNestedSolution(e ite.ExportedNested) ite.ExportedNested {
 	return ite.ExportedNested{
 		Exported: ite.Exported{A: e.A + 1, B: e.B + 2},
@@ -50,6 +110,46 @@ func exportedSolution(e ite.Exported) ite.Exported {
 	return ite.Exported{A: 1, B: 2}
 }
 
+func groupByLength(words []string) [][]string {
+	groups := make(map[int][]string)
+	lengths := make([]int, 0)
+
+	for _, w := range words {
+		if _, exists := groups[len(w)]; !exists {
+			lengths = append(lengths, len(w))
+		}
+
+		groups[len(w)] = append(groups[len(w)], w)
+	}
+
+	res := make([][]string, 0, len(lengths))
+
+	for _, l := range lengths {
+		res = append(res, groups[l])
+	}
+
+	return res
+}
+
+func goodSort(nums []int) []int {
+	sort.Ints(nums)
+	return nums
+}
+
+func insertionSort(nums []int) []int {
+	for i := 1; i < len(nums); i++ {
+		for j := i; j > 0 && nums[j-1] > nums[j]; j-- {
+			nums[j-1], nums[j] = nums[j], nums[j-1]
+		}
+	}
+
+	return nums
+}
+
+func identity[T any](v T) T {
+	return v
+}
+
 func inc(i int) int {
 	return i + 1
 }
@@ -67,6 +167,37 @@ func incMatrix(a [][]int32) [][]int32 {
 	return a
 }
 
+func int64Identity(i int) any {
+	return int64(i)
+}
+
+func isPalindrome(s string) bool {
+	for i := 0; i < len(s)/2; i++ {
+		if s[i] != s[len(s)-1-i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func leakyInc(i int) int {
+	go time.Sleep(time.Second)
+	return i + 1
+}
+
+func longestPalindrome(s string) (r string) {
+	for i := range s {
+		for j := i + len(r) + 1; j <= len(s); j++ {
+			if isPalindrome(s[i:j]) {
+				r = s[i:j]
+			}
+		}
+	}
+
+	return
+}
+
 func loopFactorial(n int) (r int) {
 	r = 1
 
@@ -77,10 +208,70 @@ func loopFactorial(n int) (r int) {
 	return
 }
 
+func minMax(nums []int) (int, int) {
+	return slices.Min(nums), slices.Max(nums)
+}
+
+func negate(i int) int {
+	return -i
+}
+
 func noInc(i int) int {
 	return i
 }
 
+func parseLoose(s string) (int, error) {
+	n, _ := strconv.Atoi(s)
+	return n, nil
+}
+
+func parseStrict(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+
+	if err != nil {
+		return 0, errors.New("not a number")
+	}
+
+	if n < 0 {
+		return 0, fmt.Errorf("parsing %s: %w", s, errNegative)
+	}
+
+	return n, nil
+}
+
+func parseUnchecked(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+func panickingFirst(nums []int) int {
+	return nums[0]
+}
+
+func panickingInc(i int) int {
+	if i%3 == 0 {
+		panic("multiple of three")
+	}
+
+	return i + 1
+}
+
+func parallelInc(i int) int {
+	var wg sync.WaitGroup
+	res := make([]int, 2)
+
+	for j := range res {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			res[j] = i + j
+		}()
+	}
+
+	wg.Wait()
+	return res[1]
+}
+
 func recursiveFactorial(n int) int {
 	if n <= 1 {
 		return 1
@@ -89,11 +280,76 @@ func recursiveFactorial(n int) int {
 	return n * recursiveFactorial(n-1)
 }
 
+func reverseHalf(s []byte) {
+	for i, j := 0, len(s)-1; i < len(s)/2-1; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func reverseInPlace(s []byte) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func (s scaler) scale(i int) int {
+	return s.factor * i
+}
+
 func runes(s []rune) []rune {
 	s[0] = 'A'
 	return s
 }
 
+func sleepyInc(ms int) int {
+	time.Sleep(time.Duration(ms) * time.Millisecond)
+	return ms + 1
+}
+
+func sortCopy(nums []int) []int {
+	res := slices.Clone(nums)
+	sort.Ints(res)
+	return res
+}
+
+func sortByAbs(nums []int) []int {
+	sort.Slice(nums, func(i, j int) bool {
+		return nums[i]*nums[i] < nums[j]*nums[j]
+	})
+	return nums
+}
+
+func sortInPlace(nums []int) {
+	sort.Ints(nums)
+}
+
+func sumCopy(nums []int) (r int) {
+	copied := make([]int, len(nums))
+	copy(copied, nums)
+
+	for _, v := range copied {
+		r += v
+	}
+
+	return
+}
+
+func sumInPlace(nums []int) (r int) {
+	for _, v := range nums {
+		r += v
+	}
+
+	return
+}
+
+func treeSize(root *treeNode) int {
+	if root == nil {
+		return 0
+	}
+
+	return 1 + treeSize(root.Left) + treeSize(root.Right)
+}
+
 func unexportedDouble(e unexported) unexported {
 	return unexported{a: e.a * 2, B: e.B * 2}
 }
@@ -105,6 +361,70 @@ func unexportedNestedInc(e unexportedNested) unexportedNested {
 	}
 }
 
+func BenchmarkSort(b *testing.B) {
+	iv := goi.NewInterview[[]int, []int]()
+	iv.AddSolutions(goodSort, insertionSort)
+	iv.ReadCases("test_data/sort_in.txt", "test_data/sort_out.txt")
+	iv.RunBenchmarks(b)
+}
+
+func FuzzSort(f *testing.F) {
+	iv := goi.NewInterview[[]int, []int]()
+	iv.AddSolutions(goodSort, insertionSort)
+	iv.ReadCases("test_data/sort_in.txt", "test_data/sort_out.txt")
+	iv.Fuzz(f, func(nums []int) []int {
+		sorted := slices.Clone(nums)
+		slices.Sort(sorted)
+		return sorted
+	})
+}
+
+func TestAlternatives(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[string, string]()
+	iv.AddCaseAlternatives("abacdc", "aba", "cdc")
+	iv.AddCaseAlternatives("xcdcaba", "aba", "cdc")
+	iv.AddCaseAlternatives("xyz", "y", "z")
+	iv.AddSolution(longestPalindrome)
+
+	t.CheckStrings(1, iv.AllSolutionsToString(), strings.Join([]string{
+		"longestPalindrome",
+		"=================",
+		"(OK) abacdc -> aba  [matched 1 of 2]",
+		"(OK) xcdcaba -> cdc  [matched 2 of 2]",
+		"(  ) xyz -> x != y | z",
+	}, "\n"))
+}
+
+func TestBenchmarks(ot *testing.T) {
+	t := ite.NewTester(ot)
+	benchtime := flag.Lookup("test.benchtime").Value
+	previous := benchtime.String()
+	ot.Cleanup(func() {
+		benchtime.Set(previous)
+	})
+
+	if err := benchtime.Set("10x"); err != nil {
+		t.Throw(1, err.Error())
+		return
+	}
+
+	iv := goi.NewInterview[[]int, int]()
+	iv.AddCase([]int{}, 0)
+	iv.AddSolution(panickingFirst)
+	calls := 0
+	iv.AddNamedSolution("safe", func(nums []int) int {
+		calls++
+		return len(nums)
+	})
+
+	testing.Benchmark(iv.RunBenchmarks)
+
+	if calls == 0 {
+		t.Throw(1, "Benchmark of safe solution did not run after a panic")
+	}
+}
+
 func TestBytes(ot *testing.T) {
 	t := ite.NewTester(ot)
 	iv := goi.NewInterview[[]byte, []byte]()
@@ -138,6 +458,191 @@ func TestBytes(ot *testing.T) {
 	})
 }
 
+func TestComparator(ot *testing.T) {
+	t := ite.NewTester(ot)
+	typed := goi.NewInterview[int, any]()
+	typed.AddCase(1, 1)
+	typed.AddSolution(int64Identity)
+
+	rec, err := typed.RunSolution("int64Identity")
+	t.CheckName(err, rec.Name, "int64Identity")
+
+	if rec.Passed != 0 || rec.Wrong != 1 {
+		t.Throw(1, "int64 output matched int, counts %d/%d", rec.Passed, rec.Wrong)
+		return
+	}
+
+	t.CheckStrings(1, rec.Lines[0].String(),
+		"(  ) 1 -> 1 != 1  [actual int64, expected int]")
+
+	iv := goi.NewInterview[int, int]()
+	iv.AddCase(3, 3)
+	iv.AddSolution(negate)
+	iv.SetComparator(func(actual, expected int) bool {
+		return actual*actual == expected*expected
+	})
+
+	rec, err = iv.RunSolution("negate")
+	t.CheckName(err, rec.Name, "negate")
+	t.CheckLines(rec.Lines, []*ite.ReceiptLine{
+		ite.NewReceiptLine("3", "-3", "3"),
+	})
+
+	if !rec.Lines[0].IsOk() {
+		t.Throw(1, "Comparator was ignored")
+	}
+}
+
+func TestComplexity(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, int]()
+	iv.AddCase([]int{1, 2, 3}, 3)
+	iv.AddSolutions(countPairsFormula, countPairsQuadratic)
+	iv.SetGenerator(func(n int) []int {
+		res := make([]int, n)
+
+		for i := range res {
+			res[i] = i
+		}
+
+		return res
+	}, 250, 500, 1000, 2000, 4000)
+
+	rec := iv.RunAllSolutions()
+	t.CheckSlice(&rec, "countPairsFormula", "countPairsQuadratic")
+	formula, quadratic := rec.Receipts[0].Complexity, rec.Receipts[1].Complexity
+
+	if formula == nil || quadratic == nil {
+		t.Throw(1, "Complexity not estimated")
+		return
+	}
+
+	if quadratic.Class != "O(n^2)" {
+		t.Throw(1, "Quadratic solution estimated as %s", quadratic)
+		return
+	}
+
+	if formula.Class == "O(n^2)" || formula.Class == "O(2^n)" {
+		t.Throw(1, "Formula solution estimated as %s", formula)
+		return
+	}
+
+	out := iv.AllSolutionsToString()
+	header := "countPairsQuadratic ~ O(n^2)\n============================\n"
+
+	if !strings.Contains(out, header) {
+		t.Throw(1, "Header not found in\n%s", out)
+		return
+	}
+
+	generated := goi.NewInterview[[]int, int]()
+	generated.AddSolution(countPairsQuadratic)
+	generated.SetGenerator(func(n int) []int {
+		return make([]int, n)
+	}, 250, 500, 1000, 2000, 4000)
+	t.CheckStrings(1, generated.AllSolutionsToString(),
+		"countPairsQuadratic ~ O(n^2)\n============================")
+}
+
+func TestConcurrent(ot *testing.T) {
+	t := ite.NewTester(ot)
+	newInterview := func() goi.Interview[int, int] {
+		iv := goi.NewInterview[int, int]()
+
+		for i := 0; i < 20; i++ {
+			iv.AddCase(i, i+1)
+		}
+
+		iv.AddSolutions(inc, negate, noInc, panickingInc)
+		return iv
+	}
+
+	sequential := newInterview()
+	expected := sequential.AllSolutionsToString()
+	concurrent := newInterview()
+	concurrent.RunConcurrently(3)
+	concurrent.RunCasesConcurrently()
+	t.CheckStrings(1, concurrent.AllSolutionsToString(), expected)
+}
+
+func TestErrors(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[string, int]()
+	iv.AddCase("12", 12)
+	iv.AddErrorCase("-3", errNegative)
+	iv.AddErrorCase("x", errors.New("not a number"))
+	iv.AddErrorSolutions(parseLoose, parseStrict, parseUnchecked)
+
+	t.CheckStrings(1, iv.AllSolutionsToString(), strings.Join([]string{
+		"parseLoose",
+		"==========",
+		"(OK) 12 -> 12",
+		"(  ) -3 -> -3 != error: negative number",
+		"(  ) x -> 0 != error: not a number",
+		"",
+		"parseStrict",
+		"===========",
+		"(OK) 12 -> 12",
+		"(OK) -3 -> error: parsing -3: negative number",
+		"(OK) x -> error: not a number",
+		"",
+		"parseUnchecked",
+		"==============",
+		"(OK) 12 -> 12",
+		"(  ) -3 -> -3 != error: negative number",
+		`(ERR) x -> error: strconv.Atoi: parsing "x": invalid syntax != error: not a number`,
+	}, "\n"))
+
+	mimic := goi.NewInterview[string, string]()
+	mimic.AddErrorCase("x", errors.New("bad"))
+	mimic.AddNamedSolution("mimic", func(s string) string {
+		return "error: bad"
+	})
+
+	rec, err := mimic.RunSolution("mimic")
+	t.CheckName(err, rec.Name, "mimic")
+
+	if rec.Passed != 0 || rec.Wrong != 1 {
+		t.Throw(1, "Output matched an expected error, counts %d/%d",
+			rec.Passed, rec.Wrong)
+		return
+	}
+
+	t.CheckStrings(1, rec.Lines[0].String(),
+		"(  ) x -> error: bad != error: bad  [output is not an error]")
+}
+
+func TestEstimateComplexity(ot *testing.T) {
+	t := ite.NewTester(ot)
+	sizes := []int{1000, 2000, 4000, 8000, 16000}
+	noise := []float64{1.03, 0.98, 1.01, 0.97, 1.02}
+	models := map[string]func(n float64) float64{
+		"O(1)":       func(n float64) float64 { return 0 },
+		"O(n)":       func(n float64) float64 { return n },
+		"O(n log n)": func(n float64) float64 { return n * math.Log2(n) },
+		"O(n^2)":     func(n float64) float64 { return n * n / 100 },
+	}
+
+	for class, model := range models {
+		times := make([]time.Duration, len(sizes))
+
+		for i, n := range sizes {
+			times[i] = time.Duration((500 + model(float64(n))) * noise[i])
+		}
+
+		if c := ite.EstimateComplexity(sizes, times); c.Class != class {
+			t.Throw(1, "%s estimated as %s from %v", class, c, times)
+			return
+		}
+	}
+
+	times := []time.Duration{210, 200, 205, 198, 640}
+
+	if c := ite.EstimateComplexity(sizes, times); c.Class != "O(1)" {
+		t.Throw(1, "Constant time with an outlier estimated as %s", c)
+	}
+}
+
 func TestExported(ot *testing.T) {
 	t := ite.NewTester(ot)
 	iv := goi.NewInterview[ite.Exported, ite.Exported]()
@@ -227,6 +732,156 @@ func TestIncMatrix(ot *testing.T) {
 	}
 }
 
+func TestInPlace(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]byte, []byte]()
+	iv.ShowBytesAsString()
+	iv.AddCaseString("hello", "olleh")
+	iv.AddCaseString("ab", "ba")
+	iv.AddInPlaceSolutions(reverseHalf, reverseInPlace)
+
+	t.CheckStrings(1, iv.AllSolutionsToString(), strings.Join([]string{
+		"reverseHalf",
+		"===========",
+		"(  ) hello -> oellh != olleh",
+		"(  ) ab -> ab != ba",
+		"",
+		"reverseInPlace",
+		"==============",
+		"(OK) hello -> olleh",
+		"(OK) ab -> ba",
+	}, "\n"))
+
+	defer func() {
+		if recover() == nil {
+			t.Throw(1, "Mismatched output type was accepted")
+		}
+	}()
+
+	mismatched := goi.NewInterview[[]byte, string]()
+	mismatched.AddInPlaceSolution(reverseInPlace)
+}
+
+func TestInputMutation(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, []int]()
+	iv.AddCase([]int{3, 1, 2}, []int{1, 2, 3})
+	iv.AddCase([]int{1, 2}, []int{1, 2})
+	iv.AddSolutions(goodSort, sortCopy)
+	iv.AddInPlaceSolution(sortInPlace)
+	iv.DetectInputMutation()
+
+	t.CheckStrings(1, iv.AllSolutionsToString(), strings.Join([]string{
+		"goodSort",
+		"========",
+		"(  ) [3 1 2] -> [1 2 3] == [1 2 3]  [input was modified]",
+		"(OK) [1 2] -> [1 2]",
+		"",
+		"sortCopy",
+		"========",
+		"(OK) [3 1 2] -> [1 2 3]",
+		"(OK) [1 2] -> [1 2]",
+		"",
+		"sortInPlace",
+		"===========",
+		"(OK) [3 1 2] -> [1 2 3]",
+		"(OK) [1 2] -> [1 2]",
+	}, "\n"))
+}
+
+func TestMemory(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, int]()
+	iv.AddCase([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 55)
+	iv.AddCase([]int{}, 0)
+	iv.AddSolutions(sumCopy, sumInPlace)
+	iv.MeasureMemory()
+
+	rec := iv.RunAllSolutions()
+	t.CheckSlice(&rec, "sumCopy", "sumInPlace")
+	copied, inPlace := rec.Receipts[0].Memory, rec.Receipts[1].Memory
+
+	if copied == nil || copied.Allocs != 1 || copied.Bytes < 80 {
+		t.Throw(1, "sumCopy allocated %v", copied)
+		return
+	}
+
+	if inPlace == nil || inPlace.Allocs != 0 || inPlace.Bytes != 0 {
+		t.Throw(1, "sumInPlace allocated %v", inPlace)
+		return
+	}
+
+	out := iv.AllSolutionsToString()
+	table := out[strings.Index(out, "Memory"):]
+
+	if !strings.HasPrefix(table, "Memory\n======\n1. sumInPlace  0 B, 0 allocs\n2. sumCopy     ") {
+		t.Throw(1, "Unexpected table\n%s", table)
+	}
+}
+
+func TestMemoryReflection(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, goi.Pair[int, int]]()
+	iv.AddCase([]int{4, -1, 2}, goi.NewPair(-1, 4))
+	iv.AddTupleSolution(minMax)
+	iv.MeasureMemory()
+
+	rec := iv.RunAllSolutions()
+	t.CheckSlice(&rec, "minMax")
+
+	if r := rec.Receipts[0]; r.Memory != nil || !r.MemorySkipped {
+		t.Throw(1, "minMax measured %v", r.Memory)
+		return
+	}
+
+	out := iv.AllSolutionsToString()
+	table := out[strings.Index(out, "Memory"):]
+	expected := "Memory\n======\n1. minMax  not measured, called by reflection"
+
+	if !strings.HasPrefix(table, expected) {
+		t.Throw(1, "Unexpected table\n%s", table)
+	}
+}
+
+func TestNamedSolutions(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[int, int]()
+	iv.AddCase(2, 2)
+	iv.AddSolution(identity[int])
+	iv.AddSolution(scaler{factor: 1}.scale)
+	iv.AddSolution(func(i int) int {
+		return i
+	})
+	iv.AddNamedSolution("double", scaler{factor: 2}.scale)
+
+	if err := iv.DescribeSolution("double", "Multiplies by two"); err != nil {
+		t.Throw(1, err.Error())
+	}
+
+	if err := iv.DescribeSolution("triple", ""); err == nil {
+		t.Throw(1, "Expected an error for an unknown solution")
+	}
+
+	slice := iv.RunAllSolutions()
+	t.CheckSlice(&slice,
+		"TestNamedSolutions.func1", "double", "identity", "scaler.scale")
+	var builder strings.Builder
+	slice.Receipts[1].ContinueBuild(&builder)
+	t.CheckStrings(1, builder.String(), ""+
+		"double\n"+
+		"======\n"+
+		"Multiplies by two\n"+
+		"(  ) 2 -> 4 != 2")
+
+	defer func() {
+		if recover() == nil {
+			t.Throw(1, "Expected a panic for a duplicate name")
+		}
+	}()
+
+	iv.AddSolution(identity[int])
+}
+
 func TestNil(ot *testing.T) {
 	t := ite.NewTester(ot)
 	iv := goi.NewInterview[*ite.ExportedNested, *ite.ExportedNested]()
@@ -235,8 +890,8 @@ func TestNil(ot *testing.T) {
 		return nil
 	})
 
-	rec, err := iv.RunSolution("func1")
-	t.CheckName(err, rec.Name, "func1")
+	rec, err := iv.RunSolution("TestNil.func1")
+	t.CheckName(err, rec.Name, "TestNil.func1")
 	t.CheckLines(rec.Lines, []*ite.ReceiptLine{
 		ite.NewReceiptLine("nil", "nil", "nil"),
 	})
@@ -254,6 +909,240 @@ func TestNoData(ot *testing.T) {
 		"No solution functions provided by the user!")
 }
 
+func TestPanic(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, int]()
+	iv.AddCase([]int{}, 0)
+	iv.AddCase([]int{4, 5}, 4)
+	iv.AddSolution(panickingFirst)
+
+	rec, err := iv.RunSolution("panickingFirst")
+	t.CheckName(err, rec.Name, "panickingFirst")
+
+	if rec.Panicked != 1 || rec.Passed != 1 || rec.Wrong != 0 {
+		t.Throw(1, "Counts %d/%d/%d", rec.Panicked, rec.Passed, rec.Wrong)
+		return
+	}
+
+	line := rec.Lines[0]
+
+	if line.Panic == nil || line.IsOk() {
+		t.Throw(1, "Panic not reported")
+		return
+	}
+
+	expected := "panic: runtime error: index out of range [0] with length 0"
+	t.CheckStrings(1, line.Actual, expected)
+
+	if len(line.Panic.Stack) == 0 ||
+		!strings.Contains(line.Panic.Stack[0], "panickingFirst") {
+		t.Throw(1, "Unexpected stack %v", line.Panic.Stack)
+	}
+}
+
+func TestPool(ot *testing.T) {
+	t := ite.NewTester(ot)
+
+	for _, workers := range []int{1, 2, 4} {
+		pool := ite.NewPool(workers)
+		var running, most atomic.Int32
+		task := func(int) {
+			current := running.Add(1)
+			defer running.Add(-1)
+
+			for previous := most.Load(); current > previous; previous = most.Load() {
+				if most.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+
+			time.Sleep(2 * time.Millisecond)
+		}
+
+		pool.Run(6, func(int) {
+			pool.Run(4, task)
+		})
+
+		if most := int(most.Load()); most > workers || (workers > 1 && most < 2) {
+			t.Throw(1, "%d tasks ran at once on %d workers", most, workers)
+			return
+		}
+	}
+}
+
+func TestRaces(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[int, int]()
+	iv.AddCase(1, 2)
+	iv.AddSolutions(leakyInc, parallelInc)
+	calls := 0
+	iv.AddNamedSolution("stateful", func(i int) int {
+		calls++
+		return i + calls%2
+	})
+	iv.CheckConcurrency(3)
+	slice := iv.RunAllSolutions()
+	t.CheckSlice(&slice, "leakyInc", "parallelInc", "stateful")
+
+	if rec := slice.Receipts[0]; rec.Leaking != 1 || rec.Passed != 0 {
+		t.Throw(1, "Leaking %d, passed %d", rec.Leaking, rec.Passed)
+	}
+
+	if rec := slice.Receipts[1]; rec.Leaking != 0 ||
+		rec.Nondeterministic != 0 || rec.Passed != 1 {
+		t.Throw(1, "Leaking %d, nondeterministic %d, passed %d",
+			rec.Leaking, rec.Nondeterministic, rec.Passed)
+	}
+
+	t.CheckStrings(1, slice.Receipts[2].Lines[0].String(),
+		"(  ) 1 -> 2 == 2  [2 distinct outputs]")
+}
+
+func TestRandomProperty(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, []int]()
+	iv.AddSolutions(goodSort, sortByAbs)
+	iv.SetProperty(func(input []int, actual []int) bool {
+		return len(input) == len(actual) && sort.IntsAreSorted(actual)
+	})
+
+	rec := iv.RandomTest(500, 1)
+
+	if len(rec.Receipts) != 2 {
+		t.Throw(1, "Expected 2 receipts, found %d", len(rec.Receipts))
+		return
+	}
+
+	good, bad := rec.Receipts[0], rec.Receipts[1]
+
+	if good.Failure != nil || bad.Failure == nil {
+		t.Throw(1, "Unexpected result\n%s", rec)
+		return
+	}
+
+	t.CheckLines([]*ite.ReceiptLine{bad.Failure}, []*ite.ReceiptLine{
+		ite.NewReceiptLine("[0 -1]", "[0 -1]", "property violated"),
+	})
+
+	zero := goi.NewInterview[int, int]()
+	zero.AddNamedSolution("zero", func(i int) int {
+		return 0
+	})
+	zero.SetProperty(func(input int, actual int) bool {
+		return actual == 2*input
+	})
+
+	rec = zero.RandomTest(100, 1)
+	t.CheckStrings(1, rec.Receipts[0].Failure.String(), "(  ) 1 -> 0 : property violated")
+}
+
+func TestRandomReference(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, []int]()
+	iv.AddSolutions(goodSort, sortByAbs)
+	iv.SetReference("goodSort")
+	dir := ot.TempDir()
+	iv.SaveRandomCases(dir)
+
+	rec := iv.RandomTest(100, 7)
+	t.CheckStrings(1, rec.String(), fmt.Sprintf(`sortByAbs
+=========
+(  ) [0 -1] -> [0 -1] != [-1 0]
+Failed on random input 3 of 100 (seed 7), shrunk in %d steps
+Saved to %s/sortByAbs_in.txt, %s/sortByAbs_out.txt`, rec.Receipts[0].Shrinks, dir, dir))
+
+	iv2 := goi.NewInterview[[]int, []int]()
+	iv2.AddSolution(sortByAbs)
+	iv2.ReadCase(dir+"/sortByAbs_in.txt", dir+"/sortByAbs_out.txt")
+
+	saved, err := iv2.RunSolution("sortByAbs")
+	t.CheckName(err, saved.Name, "sortByAbs")
+	t.CheckLines(saved.Lines, []*ite.ReceiptLine{
+		ite.NewReceiptLine("[0 -1]", "[0 -1]", "[-1 0]"),
+	})
+}
+
+func TestRandomReferenceError(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[int, int]()
+	iv.AddErrorSolution(checkedInc)
+	iv.AddSolution(inc)
+	iv.SetReference("checkedInc")
+	dir := ot.TempDir()
+	iv.SaveRandomCases(dir)
+
+	rec := iv.RandomTest(100, 1)
+	t.CheckName(nil, rec.Receipts[0].Name, "inc")
+
+	if saved := rec.Receipts[0]; saved.Failure == nil || saved.SaveError != nil ||
+		!slices.Equal(saved.Saved, []string{dir + "/inc_in.txt"}) {
+		t.Throw(1, "Unexpected result\n%s", rec)
+	}
+}
+
+func TestRandomTree(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[*treeNode, int]()
+	iv.AddSolutions(badTreeSize, treeSize)
+	iv.SetProperty(func(root *treeNode, actual int) bool {
+		return actual == treeSize(root)
+	})
+
+	rec := iv.RandomTest(200, 1)
+	t.CheckName(nil, rec.Receipts[1].Name, "treeSize")
+
+	if bad, good := rec.Receipts[0], rec.Receipts[1]; bad.Failure == nil ||
+		good.Failure != nil {
+		t.Throw(1, "Unexpected result\n%s", rec)
+		return
+	}
+
+	rng := rand.New(rand.NewPCG(1, 1))
+
+	for size := 0; size < 1000; size += 10 {
+		if n := treeSize(ite.RandomValue[*treeNode](rng, size)); n > 2*size+1 {
+			t.Throw(1, "Tree of size %d has %d nodes", size, n)
+			return
+		}
+	}
+}
+
+func TestReference(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, []int]()
+	iv.AddSolutions(badSort, goodSort)
+	iv.ReadInputs("test_data/sort_in.txt")
+
+	if _, err := iv.RunSolution("badSort"); err == nil {
+		t.Throw(1, "Missing reference not reported")
+		return
+	}
+
+	if err := iv.SetReference("unknown"); err == nil {
+		t.Throw(1, "Unknown reference accepted")
+		return
+	}
+
+	if err := iv.SetReference("goodSort"); err != nil {
+		t.Throw(1, err.Error())
+		return
+	}
+
+	rec, err := iv.RunSolution("badSort")
+	t.CheckName(err, rec.Name, "badSort")
+	t.CheckLines(rec.Lines, []*ite.ReceiptLine{
+		ite.NewReceiptLine("[1 3 5 7 9]", "[0 3 5 7 9]", "[1 3 5 7 9]"),
+		ite.NewReceiptLine("[9 0 7 8 9]", "[0 7 8 9 9]", "[0 7 8 9 9]"),
+		ite.NewReceiptLine("[3 3 3 2 2]", "[0 2 3 3 3]", "[2 2 3 3 3]"),
+		ite.NewReceiptLine("[0 0 2 -1 -3 -2]", "[0 -2 -1 0 0 2]", "[-3 -2 -1 0 0 2]"),
+		ite.NewReceiptLine("[51236 3237 908 -90000 90100]", "[0 908 3237 51236 90100]", "[-90000 908 3237 51236 90100]"),
+	})
+
+	if rec.Wrong != 4 || rec.Passed != 1 {
+		t.Throw(1, "Wrong %d, passed %d", rec.Wrong, rec.Passed)
+	}
+}
+
 func TestRunes(ot *testing.T) {
 	t := ite.NewTester(ot)
 	iv := goi.NewInterview[[]rune, []rune]()
@@ -283,6 +1172,56 @@ func TestRunes(ot *testing.T) {
 	}
 }
 
+func TestRunTests(ot *testing.T) {
+	iv := goi.NewInterview[[]int, []int]()
+	iv.AddSolutions(goodSort, insertionSort)
+	iv.ReadCases("test_data/sort_in.txt", "test_data/sort_out.txt")
+	iv.RunTests(ot)
+}
+
+func TestSolutionOrder(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[int, int]()
+	iv.AddCase(1, 2)
+	iv.AddCase(2, 3)
+	iv.AddSolutions(noInc, inc, negate)
+	iv.OrderByRegistration()
+	slice := iv.RunAllSolutions()
+	t.CheckSlice(&slice, "noInc", "inc", "negate")
+
+	iv.OrderByPassRate()
+	slice = iv.RunAllSolutions()
+	t.CheckSlice(&slice, "inc", "noInc", "negate")
+
+	iv.AddCase(1, -1)
+	slice = iv.RunAllSolutions()
+	t.CheckSlice(&slice, "inc", "negate", "noInc")
+
+	iv = goi.NewInterview[int, int]()
+	iv.AddCase(2, 3)
+	iv.AddCase(3, 4)
+	iv.AddSolutions(panickingInc, sleepyInc)
+	iv.OrderBySpeed()
+	slice = iv.RunAllSolutions()
+	t.CheckSlice(&slice, "sleepyInc", "panickingInc")
+}
+
+func TestSolutionTimeout(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[int, int]()
+	iv.AddCase(1000, 1001)
+	iv.AddCase(1, 2)
+	iv.AddSolution(sleepyInc)
+	iv.SetSolutionTimeout(50 * time.Millisecond)
+
+	rec, err := iv.RunSolution("sleepyInc")
+	t.CheckName(err, rec.Name, "sleepyInc")
+
+	if rec.TimedOut != 2 {
+		t.Throw(1, "Timed out %d", rec.TimedOut)
+	}
+}
+
 func TestSort1D(ot *testing.T) {
 	t := ite.NewTester(ot)
 	iv := goi.NewInterview[[]int, []int]()
@@ -314,6 +1253,130 @@ func TestStdout(ot *testing.T) {
 	}
 }
 
+func TestTimeout(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[int, int]()
+	iv.AddCase(1, 2)
+	iv.AddCase(1000, 1001)
+	iv.AddCase(2, 3)
+	iv.AddSolutions(inc, sleepyInc)
+	iv.SetCaseTimeout(50 * time.Millisecond)
+
+	rec, err := iv.RunSolution("sleepyInc")
+	t.CheckName(err, rec.Name, "sleepyInc")
+
+	if rec.TimedOut != 1 || rec.Passed != 2 {
+		t.Throw(1, "Timed out %d, passed %d", rec.TimedOut, rec.Passed)
+		return
+	}
+
+	t.CheckLines(rec.Lines, []*ite.ReceiptLine{
+		ite.NewReceiptLine("1", "2", "2"),
+		ite.NewTimeoutReceiptLine(50*time.Millisecond, "1001", "1000", nil),
+		ite.NewReceiptLine("2", "3", "3"),
+	})
+
+	t.CheckStrings(1, iv.AllSolutionsToString(), `inc
+===
+(OK) 1 -> 2
+(OK) 1000 -> 1001
+(OK) 2 -> 3
+
+sleepyInc
+=========
+(OK) 1 -> 2
+(TLE) 1000 -> time limit exceeded (50ms) != 1001
+(OK) 2 -> 3
+
+Summary
+=======
+sleepyInc: 1 of 3 cases timed out`)
+}
+
+func TestTiming(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[int, int]()
+	iv.AddCase(2, 3)
+	iv.AddCase(3, 4)
+	iv.AddSolutions(sleepyInc, inc)
+	iv.MeasureTime(3)
+
+	rec := iv.RunAllSolutions()
+	t.CheckSlice(&rec, "inc", "sleepyInc")
+
+	for _, r := range rec.Receipts {
+		if r.Timing == nil || r.Timed != 2 {
+			t.Throw(1, "Timing of %s not measured", r.Name)
+			return
+		}
+
+		for _, l := range r.Lines {
+			if l.Timing.Runs != 3 || l.Timing.Min > l.Timing.Median {
+				t.Throw(1, "Invalid timing %v of %s", *l.Timing, r.Name)
+				return
+			}
+		}
+	}
+
+	if sleepy := rec.Receipts[1].Timing; sleepy.Min < 5*time.Millisecond {
+		t.Throw(1, "sleepyInc took only %v", sleepy.Min)
+		return
+	}
+
+	out := iv.AllSolutionsToString()
+	ranking := out[strings.Index(out, "Ranking"):]
+
+	if !strings.HasPrefix(ranking, "Ranking\n=======\n1. inc        min ") ||
+		!strings.Contains(ranking, "\n2. sleepyInc  min ") {
+		t.Throw(1, "Unexpected ranking\n%s", ranking)
+		return
+	}
+
+	iv = goi.NewInterview[int, int]()
+	iv.AddCase(2, 3)
+	iv.AddCase(3, 4)
+	iv.AddSolutions(sleepyInc, panickingInc)
+	iv.MeasureTime(1)
+	out = iv.AllSolutionsToString()
+	ranking = out[strings.Index(out, "Ranking"):]
+
+	if !strings.HasPrefix(ranking, "Ranking\n=======\n1. sleepyInc ") ||
+		!strings.Contains(ranking, "\n2. panickingInc ") ||
+		!strings.Contains(ranking, " (1 of 2 cases)") {
+		t.Throw(1, "Incomplete timing ranked first\n%s", ranking)
+	}
+}
+
+func TestTuple(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewInterview[[]int, goi.Pair[int, int]]()
+	iv.AddCase([]int{1, 2, 3}, goi.NewPair(1, 3))
+	iv.AddCase([]int{4, -1, 2}, goi.NewPair(-1, 4))
+	iv.AddTupleSolutions(badMinMax, minMax)
+
+	rec, err := iv.RunSolution("badMinMax")
+	t.CheckName(err, rec.Name, "badMinMax")
+	t.CheckLines(rec.Lines, []*ite.ReceiptLine{
+		ite.NewReceiptLine("[1 2 3]", "1, 3", "1, 3"),
+		ite.NewReceiptLine("[4 -1 2]", "4, 2", "-1, 4"),
+	})
+
+	rec, err = iv.RunSolution("minMax")
+	t.CheckName(err, rec.Name, "minMax")
+
+	if rec.Passed != 2 {
+		t.Throw(1, "Passed %d", rec.Passed)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Throw(1, "expected a panic for mismatched outputs")
+		}
+	}()
+
+	iv.AddTupleSolution(sumCopy)
+}
+
 func TestUnexported(ot *testing.T) {
 	t := ite.NewTester(ot)
 	iv := goi.NewInterview[unexported, unexported]()
@@ -348,3 +1411,35 @@ func TestUnexportedNested(ot *testing.T) {
 			"{unexported:{a:2 B:4} C:100}"),
 	})
 }
+
+func TestUnordered(ot *testing.T) {
+	t := ite.NewTester(ot)
+	words := []string{"go", "test", "is", "fine", "a"}
+	expected := [][]string{{"a"}, {"is", "go"}, {"fine", "test"}}
+
+	top := goi.NewInterview[[]string, [][]string]()
+	top.AddCase(words, [][]string{{"a"}, {"go", "is"}, {"test", "fine"}})
+	top.AddCase(words, expected)
+	top.AddSolution(groupByLength)
+	top.IgnoreOrder()
+
+	rec, err := top.RunSolution("groupByLength")
+	t.CheckName(err, rec.Name, "groupByLength")
+
+	if rec.Passed != 1 || rec.Wrong != 1 {
+		t.Throw(1, "Counts %d/%d", rec.Passed, rec.Wrong)
+		return
+	}
+
+	nested := goi.NewInterview[[]string, [][]string]()
+	nested.AddCase(words, expected)
+	nested.AddSolution(groupByLength)
+	nested.IgnoreNestedOrder()
+
+	rec, err = nested.RunSolution("groupByLength")
+	t.CheckName(err, rec.Name, "groupByLength")
+
+	if rec.Passed != 1 {
+		t.Throw(1, "Order of nested slices was not ignored")
+	}
+}
@@ -0,0 +1,289 @@
+package gointerview
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	r "reflect"
+	"slices"
+	"testing"
+
+	ite "github.com/Matej-Chmel/go-interview/internal"
+)
+
+// Class for problems with any number of inputs.
+// Solutions are functions of any signature returning the output
+// and optionally an error. They are called by reflection.
+// Input types are taken from the first solution or the first case,
+// whichever is added first, and all others must match them.
+// Delegates all implementation to Interview2 with the arguments
+// of a solution as the first input.
+type InterviewN[O any] struct {
+	*ite.EmbeddedOptions
+	inputTypes []r.Type
+	iv         Interview2[ite.Args, int, O]
+}
+
+// Constructs an InterviewN object
+func NewInterviewN[O any]() InterviewN[O] {
+	options := ite.NewEmbeddedOptions()
+
+	return InterviewN[O]{
+		EmbeddedOptions: options,
+		inputTypes:      nil,
+		iv:              newInterview2Impl[ite.Args, int, O](true, options),
+	}
+}
+
+// Adds one test case. The last value is the expected output
+// and the values before it are the inputs.
+// Numbers are converted to the input and output types.
+// Panics if the values don't match the types.
+func (iv *InterviewN[O]) AddCase(values ...any) {
+	if len(values) == 0 {
+		panic(errors.New("expected output is missing"))
+	}
+
+	last := len(values) - 1
+	args := iv.newArgs(values[:last])
+	expected, err := ite.ConvertValue(values[last], r.TypeFor[O]())
+
+	if err != nil {
+		panic(fmt.Errorf("expected output: %w", err))
+	}
+
+	out, _ := expected.Interface().(O)
+	iv.iv.AddCase(args, 0, out)
+}
+
+// Adds one test case without an expected output.
+// The expected output is computed by the reference solution.
+// Panics if the inputs don't match the input types.
+func (iv *InterviewN[O]) AddInput(inputs ...any) {
+	iv.iv.AddInput(iv.newArgs(inputs), 0)
+}
+
+// Adds one solution function. The function returns either the output
+// or the fields of a struct output such as Pair or Triple in order.
+// The results can be followed by an error. Panics if s is not
+// such a function, if its inputs don't match the input types
+// or if a solution with the same name was already added.
+func (iv *InterviewN[O]) AddSolution(s any) {
+	iv.AddNamedSolution(ite.GetFunctionName(s), s)
+}
+
+// Adds one solution function under the given name.
+// Panics like AddSolution.
+func (iv *InterviewN[O]) AddNamedSolution(name string, s any) {
+	iv.iv.addSolution1(name, iv.wrapSolution(s))
+	iv.iv.reflective[name] = true
+}
+
+// Adds multiple solution functions
+func (iv *InterviewN[O]) AddSolutions(s ...any) {
+	for _, f := range s {
+		iv.AddSolution(f)
+	}
+}
+
+// Runs all solutions against all test cases
+// and compiles the output into a single string
+func (iv *InterviewN[O]) AllSolutionsToString() string {
+	return iv.iv.AllSolutionsToString()
+}
+
+// Sets a short description of the named solution
+// that is shown under its name in the output.
+// If the solution cannot be found, an error is returned.
+func (iv *InterviewN[O]) DescribeSolution(name, description string) error {
+	return iv.iv.DescribeSolution(name, description)
+}
+
+// Ignores the order of elements of an output slice or array.
+// For map outputs, the order of elements of slices stored
+// as map values is ignored. Nested slices keep their order.
+func (iv *InterviewN[O]) IgnoreOrder() {
+	iv.iv.IgnoreOrder()
+}
+
+// Ignores the order of elements at every level of nested
+// slices and arrays in the output
+func (iv *InterviewN[O]) IgnoreNestedOrder() {
+	iv.iv.IgnoreNestedOrder()
+}
+
+// Constructs arguments of a solution from inputs.
+// Input types are set from the inputs if they are not known yet.
+// Panics if the inputs don't match the input types.
+func (iv *InterviewN[O]) newArgs(inputs []any) ite.Args {
+	if iv.inputTypes == nil {
+		types := make([]r.Type, len(inputs))
+
+		for i, input := range inputs {
+			if types[i] = r.TypeOf(input); types[i] == nil {
+				panic(fmt.Errorf("type of input %d is unknown", i+1))
+			}
+		}
+
+		iv.inputTypes = types
+	}
+
+	args, err := ite.NewArgs(inputs, iv.inputTypes)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return args
+}
+
+// Runs all solutions against all test cases
+// and prints the output to the standard output
+func (iv *InterviewN[O]) Print() error {
+	return iv.iv.Print()
+}
+
+// Reads one case from relative paths. The last path is for the output
+// and the paths before it are for the inputs.
+// Panics if the input types are not known yet or a file cannot be read.
+func (iv *InterviewN[O]) ReadCase(relPaths ...string) {
+	inputs, out := iv.readColumns(relPaths, false)
+	expected, _ := out.Interface().(O)
+	iv.iv.AddCase(iv.newArgs(inputs), 0, expected)
+}
+
+// Reads multiple cases from relative paths. The last path is
+// for the outputs and the paths before it are for the inputs.
+// Panics if the input types are not known yet, a file cannot be read
+// or the numbers of cases in files differ.
+func (iv *InterviewN[O]) ReadCases(relPaths ...string) {
+	inputs, out := iv.readColumns(relPaths, true)
+
+	for i := 0; i < out.Len(); i++ {
+		values := make([]any, len(inputs))
+
+		for j, input := range inputs {
+			values[j] = r.ValueOf(input).Index(i).Interface()
+		}
+
+		expected, _ := out.Index(i).Interface().(O)
+		iv.iv.AddCase(iv.newArgs(values), 0, expected)
+	}
+}
+
+// Reads inputs and outputs from files on relative paths.
+// If multiple is true, each file holds a slice of values.
+func (iv *InterviewN[O]) readColumns(
+	relPaths []string, multiple bool,
+) ([]any, r.Value) {
+	if iv.inputTypes == nil {
+		panic(errors.New("input types are unknown, add a solution first"))
+	}
+
+	if len(relPaths) != len(iv.inputTypes)+1 {
+		panic(fmt.Errorf("expected %d paths, found %d",
+			len(iv.inputTypes)+1, len(relPaths)))
+	}
+
+	types := append(slices.Clone(iv.inputTypes), r.TypeFor[O]())
+	columns := make([]r.Value, len(relPaths))
+
+	for i, relPath := range relPaths {
+		t := types[i]
+
+		if multiple {
+			t = r.SliceOf(t)
+		}
+
+		val, err := ite.ReadValue(relPath, t)
+
+		if err != nil {
+			panic(err)
+		}
+
+		if multiple && i > 0 && val.Len() != columns[0].Len() {
+			panic(fmt.Errorf("Length of inputs don't match %d:%d",
+				columns[0].Len(), val.Len()))
+		}
+
+		columns[i] = val
+	}
+
+	last := len(columns) - 1
+	inputs := make([]any, last)
+
+	for i := range inputs {
+		inputs[i] = columns[i].Interface()
+	}
+
+	return inputs, columns[last]
+}
+
+// Runs one solution function against all test cases.
+// If function cannot be found, an error is returned.
+func (iv *InterviewN[O]) RunSolution(name string) (ite.Receipt, error) {
+	return iv.iv.RunSolution(name)
+}
+
+// Runs a sub-benchmark for every pair of a solution and a test case
+// named after the solution and the case index
+func (iv *InterviewN[O]) RunBenchmarks(b *testing.B) {
+	b.Helper()
+	iv.iv.RunBenchmarks(b)
+}
+
+// Runs all solutions against all test cases
+func (iv *InterviewN[O]) RunAllSolutions() ite.ReceiptSlice {
+	return iv.iv.RunAllSolutions()
+}
+
+// Runs every solution as a subtest named after the solution
+// with one subtest per test case named after the case index
+func (iv *InterviewN[O]) RunTests(t *testing.T) {
+	t.Helper()
+	iv.iv.RunTests(t)
+}
+
+// Sets a function that decides whether the actual output
+// of a solution matches the expected one.
+// Nil restores the default typed deep equality.
+func (iv *InterviewN[O]) SetComparator(comparator func(actual, expected O) bool) {
+	iv.iv.SetComparator(comparator)
+}
+
+// Marks a registered solution as the reference. Expected outputs
+// of cases added without them are computed by this solution
+// and all other solutions are compared against them.
+// If the solution cannot be found, an error is returned.
+func (iv *InterviewN[O]) SetReference(name string) error {
+	return iv.iv.SetReference(name)
+}
+
+// Returns a function that calls the solution s by reflection.
+// Input types are set from s if they are not known yet.
+// Panics if the signature of s doesn't match the types.
+func (iv *InterviewN[O]) wrapSolution(s any) func(ite.Args) (O, error) {
+	if t := r.TypeOf(s); iv.inputTypes == nil && t != nil && t.Kind() == r.Func {
+		iv.inputTypes = make([]r.Type, t.NumIn())
+
+		for i := range iv.inputTypes {
+			iv.inputTypes[i] = t.In(i)
+		}
+	}
+
+	f, err := ite.WrapFunction[O](s, iv.inputTypes)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return func(args ite.Args) (O, error) {
+		return f(args.Values())
+	}
+}
+
+// Runs all solutions against all test cases
+// and writes the results into a writer w
+func (iv *InterviewN[O]) WriteAllSolutions(w io.Writer) error {
+	return iv.iv.WriteAllSolutions(w)
+}
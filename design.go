@@ -0,0 +1,489 @@
+package gointerview
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	r "reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	at "github.com/Matej-Chmel/go-any-to-string"
+	ite "github.com/Matej-Chmel/go-interview/internal"
+)
+
+// Class for design problems where a solution is a struct
+// whose methods are called in a sequence of operations.
+// Solutions are constructors of such structs. The first operation
+// of each case calls the constructor and the following operations
+// call methods by reflection. An operation matches a method
+// either by its exact name or with its first letter in upper case.
+type DesignInterview struct {
+	*ite.EmbeddedOptions
+	cases        []*designCase
+	constructors *ite.Registry[any]
+	descriptions map[string]string
+}
+
+// Sequence of operations with their arguments and expected results.
+// Nil expected result is not checked.
+type designCase struct {
+	args       [][]any
+	expected   []any
+	operations []string
+}
+
+// Strings shown for each executed step of a design case.
+// Diverged is the first step whose result differs from the expected one
+// or -1 if there is no such step.
+type designRun struct {
+	actual   []string
+	diverged int
+	expected []string
+	steps    []string
+}
+
+// Constructs a DesignInterview object
+func NewDesignInterview() DesignInterview {
+	return DesignInterview{
+		EmbeddedOptions: ite.NewEmbeddedOptions(),
+		cases:           make([]*designCase, 0),
+		constructors:    ite.NewRegistry[any](),
+		descriptions:    make(map[string]string),
+	}
+}
+
+// Adds one test case. Each operation has a slice of arguments
+// and an expected result. The first operation is the constructor
+// and its expected result is ignored.
+// Panics if the lengths of the slices differ or if they are empty.
+func (iv *DesignInterview) AddCase(
+	operations []string, args [][]any, expected []any,
+) {
+	if len(operations) == 0 {
+		panic(errors.New("at least one operation is required"))
+	}
+
+	if len(operations) != len(args) || len(operations) != len(expected) {
+		panic(fmt.Errorf("Length of operations, arguments and results "+
+			"don't match %d:%d:%d", len(operations), len(args), len(expected)))
+	}
+
+	iv.cases = append(iv.cases, &designCase{
+		args:       args,
+		expected:   expected,
+		operations: operations,
+	})
+}
+
+// Adds one test case from JSON arrays of operations, arguments
+// and expected results in the format used by LeetCode, for example
+// ["LRUCache","put","get"], [[2],[1,1],[1]] and [null,null,1].
+// Panics if any string is not a valid JSON array.
+func (iv *DesignInterview) AddCaseString(operations, args, expected string) {
+	var ops []string
+	var argValues [][]any
+	var results []any
+
+	for _, err := range []error{
+		json.Unmarshal([]byte(operations), &ops),
+		json.Unmarshal([]byte(args), &argValues),
+		json.Unmarshal([]byte(expected), &results),
+	} {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	iv.AddCase(ops, argValues, results)
+}
+
+// Adds one solution as a constructor of a struct
+// named after the constructor. Panics if the constructor
+// is not a function returning one value or if a solution
+// with the same name was already added.
+func (iv *DesignInterview) AddSolution(constructor any) {
+	iv.AddNamedSolution(ite.GetFunctionName(constructor), constructor)
+}
+
+// Adds one solution as a constructor of a struct under the given name.
+// Panics like AddSolution.
+func (iv *DesignInterview) AddNamedSolution(name string, constructor any) {
+	t := r.TypeOf(constructor)
+
+	if t == nil || t.Kind() != r.Func || t.NumOut() != 1 {
+		panic(fmt.Errorf(
+			"constructor must be a function returning one value, found %v", t))
+	}
+
+	panicIfDuplicate(iv.constructors.Add(name, constructor))
+}
+
+// Adds multiple solutions as constructors of structs
+func (iv *DesignInterview) AddSolutions(constructors ...any) {
+	for _, c := range constructors {
+		iv.AddSolution(c)
+	}
+}
+
+// Runs all solutions against all test cases
+// and compiles the output into a single string
+func (iv *DesignInterview) AllSolutionsToString() string {
+	var builder strings.Builder
+	iv.WriteAllSolutions(&builder)
+	return builder.String()
+}
+
+// Sets a short description of the named solution
+// that is shown under its name in the output.
+// If the solution cannot be found, an error is returned.
+func (iv *DesignInterview) DescribeSolution(name, description string) error {
+	if !iv.constructors.Has(name) {
+		return fmt.Errorf("solution %s not found", name)
+	}
+
+	iv.descriptions[name] = description
+	return nil
+}
+
+// Returns the method of obj matching the operation
+// or an invalid value if there is none
+func findMethod(obj r.Value, operation string) r.Value {
+	if method := obj.MethodByName(operation); method.IsValid() {
+		return method
+	}
+
+	first, size := utf8.DecodeRuneInString(operation)
+	exported := string(unicode.ToUpper(first)) + operation[size:]
+	return obj.MethodByName(exported)
+}
+
+// Constructs a ReceiptLine from the result of replaying a case
+func (iv *DesignInterview) newReceiptLine(
+	res ite.CallResult[struct{}], run *designRun, limit time.Duration,
+	c *designCase,
+) *ite.ReceiptLine {
+	if res.TimedOut {
+		steps, expected := iv.caseStrings(c)
+		return ite.NewTimeoutReceiptLine(limit, expected, steps, nil)
+	}
+
+	if res.Panic != nil {
+		run.fail(res.Panic.String(), c, iv.GetOptions())
+	} else if res.Err != nil {
+		run.fail(ite.ErrorString(res.Err), c, iv.GetOptions())
+	}
+
+	line := ite.NewReceiptLineImpl(
+		strings.Join(run.actual, "\n"),
+		strings.Join(run.expected, "\n"),
+		strings.Join(run.steps, "\n"),
+		nil)
+	line.Diverged = run.diverged
+	line.Errored = res.Err != nil
+	line.Ok = run.diverged < 0
+	line.Panic = res.Panic
+	line.Steps = true
+	return line
+}
+
+// Returns operations and expected results of a case
+// each on a separate line
+func (iv *DesignInterview) caseStrings(c *designCase) (string, string) {
+	options := iv.GetOptions()
+	steps := make([]string, len(c.operations))
+	expected := make([]string, len(c.operations))
+
+	for i, op := range c.operations {
+		steps[i] = stepString(op, c.args[i], options)
+		expected[i] = resultString(c.expected[i], options)
+	}
+
+	return strings.Join(steps, "\n"), strings.Join(expected, "\n")
+}
+
+// Records the step of case c that failed with actual output
+// shown instead of its result
+func (run *designRun) fail(actual string, c *designCase, o *at.Options) {
+	i := len(run.actual)
+
+	if len(run.steps) == i {
+		run.steps = append(run.steps, stepString(c.operations[i], c.args[i], o))
+	}
+
+	run.mismatch(actual, resultString(c.expected[i], o))
+}
+
+// Runs all solutions against all test cases
+// and prints the output to the standard output
+func (iv *DesignInterview) Print() error {
+	return iv.WriteAllSolutions(os.Stdout)
+}
+
+// Replays the operations of case c on a struct made by the constructor.
+// Each step is recorded into run as soon as it finishes,
+// so the steps are available even if a later step panics.
+// Returns an error if an operation cannot be called.
+func (iv *DesignInterview) replay(
+	constructor r.Value, c *designCase, run *designRun,
+) error {
+	options := iv.GetOptions()
+	config := ite.EqualConfig{Order: ite.Ordered, Tolerance: iv.GetFloatTolerance()}
+	var obj r.Value
+
+	for i, op := range c.operations {
+		fn := constructor
+
+		if i > 0 {
+			if fn = findMethod(obj, op); !fn.IsValid() {
+				return fmt.Errorf("no method %s", op)
+			}
+		}
+
+		args, err := stepArgs(fn.Type(), c.args[i])
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		run.steps = append(run.steps, stepString(op, args, options))
+		results := fn.Call(args.Values())
+
+		if i == 0 {
+			obj = addressable(results[0])
+			run.pass("null", "")
+			continue
+		}
+
+		switch len(results) {
+		case 0:
+			if c.expected[i] != nil {
+				run.mismatch("null", resultString(c.expected[i], options))
+			} else {
+				run.pass("null", "")
+			}
+		case 1:
+			actual := ite.OutputString(results[0].Interface(), options)
+
+			if c.expected[i] == nil {
+				run.pass(actual, "")
+				break
+			}
+
+			expected, err := ite.ConvertValue(c.expected[i], results[0].Type())
+
+			if err != nil {
+				run.mismatch(actual, resultString(c.expected[i], options))
+			} else if ok, _ := ite.ConfigEqual(
+				results[0].Interface(), expected.Interface(), config); ok {
+				run.pass(actual, "")
+			} else {
+				run.mismatch(actual, ite.OutputString(expected.Interface(), options))
+			}
+		default:
+			return fmt.Errorf("%s returns %d values", op, len(results))
+		}
+	}
+
+	return nil
+}
+
+// Records a step with the expected result
+func (run *designRun) pass(actual, expected string) {
+	run.actual = append(run.actual, actual)
+	run.expected = append(run.expected, expected)
+}
+
+// Records a step with a different result than expected.
+// Only the first such step is marked as diverged.
+func (run *designRun) mismatch(actual, expected string) {
+	if run.diverged >= 0 {
+		run.pass(actual, "")
+		return
+	}
+
+	run.pass(actual, expected)
+	run.diverged = len(run.actual) - 1
+}
+
+// Returns a string representing a result of an operation
+func resultString(result any, o *at.Options) string {
+	if result == nil {
+		return "null"
+	}
+
+	return ite.OutputString(result, o)
+}
+
+// Runs one solution against all test cases.
+// If the solution cannot be found, an error is returned.
+func (iv *DesignInterview) RunSolution(name string) (ite.Receipt, error) {
+	constructor, exists := iv.constructors.Get(name)
+
+	if !exists {
+		res := ite.Receipt{Lines: nil, Name: ""}
+		return res, fmt.Errorf("solution %s not found", name)
+	}
+
+	return iv.runCases(name, constructor), nil
+}
+
+// Runs all solutions against all test cases
+func (iv *DesignInterview) RunAllSolutions() ite.ReceiptSlice {
+	return ite.ExecuteSolutions(
+		iv.constructors, iv.runCases, iv.GetSolutionOrder(), iv.GetPool())
+}
+
+// Replays all test cases on structs made by the constructor.
+// Panics are recovered and time limits are enforced per case and
+// per solution. Once the solution limit is exhausted,
+// the remaining cases are reported as timed out without running.
+func (iv *DesignInterview) runCases(name string, constructor any) ite.Receipt {
+	caseLimit, solutionLimit := iv.GetCaseTimeout(), iv.GetSolutionTimeout()
+	fn := r.ValueOf(constructor)
+	lines := make([]*ite.ReceiptLine, len(iv.cases))
+	pool := ite.NewPool(1)
+	start := time.Now()
+
+	if iv.AreCasesConcurrent() {
+		pool = iv.GetPool()
+	}
+
+	pool.Run(len(iv.cases), func(i int) {
+		c, limit := iv.cases[i], caseLimit
+
+		if solutionLimit > 0 {
+			remaining := solutionLimit - time.Since(start)
+
+			if remaining <= 0 {
+				res := ite.CallResult[struct{}]{TimedOut: true}
+				lines[i] = iv.newReceiptLine(res, nil, solutionLimit, c)
+				return
+			}
+
+			if limit <= 0 || remaining < limit {
+				limit = remaining
+			}
+		}
+
+		res, run := iv.runCase(fn, c, limit)
+
+		if res.TimedOut && (caseLimit <= 0 || limit < caseLimit) {
+			limit = solutionLimit
+		}
+
+		lines[i] = iv.newReceiptLine(res, run, limit, c)
+	})
+
+	res := ite.NewReceipt(name, lines)
+	res.Description = iv.descriptions[name]
+	res.Elapsed = time.Since(start)
+	return res
+}
+
+// Replays case c on a struct made by the constructor
+// with a time limit and recovers from any panic
+func (iv *DesignInterview) runCase(
+	constructor r.Value, c *designCase, limit time.Duration,
+) (ite.CallResult[struct{}], *designRun) {
+	run := &designRun{
+		actual:   nil,
+		diverged: -1,
+		expected: nil,
+		steps:    nil,
+	}
+	res := ite.Call(func() (struct{}, error) {
+		return struct{}{}, iv.replay(constructor, c, run)
+	}, ite.CallConfig{Limit: limit, MeasureMemory: false})
+	return res, run
+}
+
+// Runs every solution as a subtest named after the solution
+// with one subtest per test case named after the case index
+func (iv *DesignInterview) RunTests(t *testing.T) {
+	t.Helper()
+
+	alphabetical := iv.GetSolutionOrder() == ite.Alphabetical
+
+	for _, name := range iv.constructors.Names(alphabetical) {
+		constructor, _ := iv.constructors.Get(name)
+		fn := r.ValueOf(constructor)
+
+		t.Run(name, func(t *testing.T) {
+			for i, c := range iv.cases {
+				t.Run(strconv.Itoa(i), func(t *testing.T) {
+					limit := iv.GetCaseTimeout()
+					res, run := iv.runCase(fn, c, limit)
+					line := iv.newReceiptLine(res, run, limit, c)
+
+					if !line.IsOk() {
+						t.Error("\n" + line.String())
+					}
+				})
+			}
+		})
+	}
+}
+
+// Returns the arguments converted to the input types of function type t
+func stepArgs(t r.Type, values []any) (ite.Args, error) {
+	if t.IsVariadic() {
+		return nil, errors.New("variadic functions are not supported")
+	}
+
+	types := make([]r.Type, t.NumIn())
+
+	for i := range types {
+		types[i] = t.In(i)
+	}
+
+	return ite.NewArgs(values, types)
+}
+
+// Returns a string representing an operation called with arguments
+func stepString(operation string, args []any, o *at.Options) string {
+	parts := make([]string, len(args))
+
+	for i, arg := range args {
+		parts[i] = at.AnyToStringCustom(arg, o)
+	}
+
+	return fmt.Sprintf("%s(%s)", operation, strings.Join(parts, ", "))
+}
+
+// Runs all solutions against all test cases
+// and writes the results into a writer w
+func (iv *DesignInterview) WriteAllSolutions(w io.Writer) error {
+	var err error
+
+	if len(iv.cases) == 0 {
+		_, err = w.Write([]byte("No test cases provided by the user!"))
+	} else if iv.constructors.Len() == 0 {
+		_, err = w.Write([]byte("No solution functions provided by the user!"))
+	} else {
+		var builder strings.Builder
+		slice := iv.RunAllSolutions()
+		slice.ContinueBuild(&builder)
+		_, err = w.Write([]byte(builder.String()))
+	}
+
+	return err
+}
+
+// Returns obj or a pointer to its copy if it is not a pointer,
+// so that methods with pointer receivers can be called
+func addressable(obj r.Value) r.Value {
+	if obj.Kind() == r.Pointer || obj.Kind() == r.Interface {
+		return obj
+	}
+
+	res := r.New(obj.Type())
+	res.Elem().Set(obj)
+	return res
+}
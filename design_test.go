@@ -0,0 +1,86 @@
+package gointerview_test
+
+import (
+	"strings"
+	"testing"
+
+	goi "github.com/Matej-Chmel/go-interview"
+	ite "github.com/Matej-Chmel/go-interview/internal"
+)
+
+type firstMinStack struct {
+	data []int
+}
+
+type minStack struct {
+	data []int
+	mins []int
+}
+
+func newFirstMinStack() *firstMinStack {
+	return &firstMinStack{data: nil}
+}
+
+func newMinStack() *minStack {
+	return &minStack{data: nil, mins: nil}
+}
+
+func (s *firstMinStack) GetMin() int {
+	return s.data[0]
+}
+
+func (s *firstMinStack) Pop() {
+	s.data = s.data[:len(s.data)-1]
+}
+
+func (s *firstMinStack) Push(v int) {
+	s.data = append(s.data, v)
+}
+
+func (s *minStack) GetMin() int {
+	return s.mins[len(s.mins)-1]
+}
+
+func (s *minStack) Pop() {
+	if s.data[len(s.data)-1] == s.GetMin() {
+		s.mins = s.mins[:len(s.mins)-1]
+	}
+
+	s.data = s.data[:len(s.data)-1]
+}
+
+func (s *minStack) Push(v int) {
+	s.data = append(s.data, v)
+
+	if len(s.mins) == 0 || v <= s.GetMin() {
+		s.mins = append(s.mins, v)
+	}
+}
+
+func TestDesign(ot *testing.T) {
+	t := ite.NewTester(ot)
+	iv := goi.NewDesignInterview()
+	iv.AddSolutions(newFirstMinStack, newMinStack)
+	iv.AddCaseString(
+		`["MinStack","push","push","getMin","pop","getMin"]`,
+		`[[],[0],[-2],[],[],[]]`,
+		`[null,null,null,-2,null,0]`)
+	iv.AddCase(
+		[]string{"MinStack", "peek"},
+		[][]any{{}, {}},
+		[]any{nil, 1})
+
+	rec, err := iv.RunSolution("newFirstMinStack")
+	t.CheckName(err, rec.Name, "newFirstMinStack")
+	steps := strings.Split(rec.Lines[0].String(), "\n")
+	t.CheckStrings(1, steps[3], "     getMin()   -> 0    != -2")
+	steps = strings.Split(rec.Lines[1].String(), "\n")
+	t.CheckStrings(1, steps[1], "      peek()     -> error: no method peek != 1")
+
+	rec, err = iv.RunSolution("newMinStack")
+	t.CheckName(err, rec.Name, "newMinStack")
+
+	if rec.Passed != 1 {
+		t.Throw(1, "Passed %d", rec.Passed)
+	}
+}
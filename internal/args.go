@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"fmt"
+	r "reflect"
+
+	at "github.com/Matej-Chmel/go-any-to-string"
+	dc "github.com/Matej-Chmel/go-deep-copy"
+)
+
+// Arguments of a solution with any number of inputs
+type Args []any
+
+// Converts values to the given types.
+// Returns an error if the counts differ or a value cannot be converted.
+func NewArgs(values []any, types []r.Type) (Args, error) {
+	if len(values) != len(types) {
+		return nil, fmt.Errorf(
+			"expected %d inputs, found %d", len(types), len(values))
+	}
+
+	res := make(Args, len(values))
+
+	for i, v := range values {
+		val, err := ConvertValue(v, types[i])
+
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i+1, err)
+		}
+
+		res[i] = val.Interface()
+	}
+
+	return res, nil
+}
+
+// Returns a deep copy of the arguments
+func (a Args) Copy() Args {
+	res := make(Args, len(a))
+
+	for i, v := range a {
+		if v != nil {
+			res[i] = dc.DeepCopy(v)
+		}
+	}
+
+	return res
+}
+
+// Returns string representations of all arguments
+func (a Args) Strings(o *at.Options) []string {
+	res := make([]string, len(a))
+
+	for i, v := range a {
+		res[i] = at.AnyToStringCustom(v, o)
+	}
+
+	return res
+}
+
+// Returns the arguments as values for a reflective call
+func (a Args) Values() []r.Value {
+	res := make([]r.Value, len(a))
+
+	for i, v := range a {
+		res[i] = r.ValueOf(v)
+	}
+
+	return res
+}
+
+// Converts value to type t. Values of other numeric types are converted
+// and slices are converted element by element, for example []any
+// decoded from JSON. Other values must be assignable to t.
+func ConvertValue(value any, t r.Type) (r.Value, error) {
+	val := r.ValueOf(value)
+
+	if !val.IsValid() {
+		return r.Zero(t), nil
+	}
+
+	if val.Type().AssignableTo(t) {
+		res := r.New(t).Elem()
+		res.Set(val)
+		return res, nil
+	}
+
+	if val.Kind() == r.Slice && t.Kind() == r.Slice {
+		res := r.MakeSlice(t, val.Len(), val.Len())
+
+		for i := 0; i < val.Len(); i++ {
+			elem, err := ConvertValue(val.Index(i).Interface(), t.Elem())
+
+			if err != nil {
+				return r.Value{}, err
+			}
+
+			res.Index(i).Set(elem)
+		}
+
+		return res, nil
+	}
+
+	if isNumber(val.Kind()) && isNumber(t.Kind()) && val.CanConvert(t) {
+		return val.Convert(t), nil
+	}
+
+	return r.Value{}, fmt.Errorf("cannot use %v as %v", val.Type(), t)
+}
+
+// Copies the value p points to.
+// Values of Args are copied one by one.
+func DeepCopy[T any](p *T) *T {
+	if args, ok := any(p).(*Args); ok && args != nil {
+		res := args.Copy()
+		return any(&res).(*T)
+	}
+
+	return dc.DeepCopy(p)
+}
+
+// Returns true if values of kind k are numbers
+func isNumber(k r.Kind) bool {
+	return r.Int <= k && k <= r.Complex128
+}
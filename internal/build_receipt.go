@@ -4,32 +4,42 @@ import "strings"
 
 // Writes all receipt lines from col to builder
 func WriteCollection(builder *strings.Builder, col *IteratorCollection) bool {
-	if col.ok {
-		builder.WriteString("(OK) ")
-	} else {
-		builder.WriteString("(  ) ")
-	}
+	marker := col.Marker()
+	indent := strings.Repeat(" ", len(marker)+1)
+	builder.WriteString(marker)
+	builder.WriteRune(' ')
 
 	center := (col.maxHeight - (1 - (col.maxHeight & 1))) / 2
 	last := col.maxHeight - 1
+	mismatch := " != "
+	mismatchLine := center
+
+	if col.Steps {
+		mismatchLine = col.Diverged
+	}
+
+	if col.Mismatch != "" {
+		mismatch = " " + col.Mismatch + " "
+	}
 
 	for i := 0; i < col.maxHeight; i++ {
 		if i > 0 {
-			builder.WriteString("     ")
+			builder.WriteString(indent)
 		}
 
 		col.WriteInput(builder, i)
 
 		if col.input2 != nil {
-			if i == center {
-				builder.WriteString(", ")
-			} else {
-				builder.WriteString("  ")
-			}
+			writeSeparator(builder, i == center)
 			col.WriteInput2(builder, i)
 		}
 
-		if i == center {
+		for j := range col.more {
+			writeSeparator(builder, i == center)
+			col.WriteMoreInput(builder, j, i)
+		}
+
+		if i == center || col.Steps {
 			builder.WriteString(" -> ")
 		} else {
 			builder.WriteString("    ")
@@ -38,15 +48,19 @@ func WriteCollection(builder *strings.Builder, col *IteratorCollection) bool {
 		col.WriteActual(builder, i)
 
 		if !col.ok {
-			if i == center {
-				builder.WriteString(" != ")
+			if i == mismatchLine {
+				builder.WriteString(mismatch)
 			} else {
-				builder.WriteString("    ")
+				builder.WriteString(strings.Repeat(" ", len(mismatch)))
 			}
 
 			col.WriteExpected(builder, i)
 		}
 
+		if i == center {
+			builder.WriteString(col.Suffix)
+		}
+
 		if i < last {
 			builder.WriteRune('\n')
 		}
@@ -54,3 +68,13 @@ func WriteCollection(builder *strings.Builder, col *IteratorCollection) bool {
 
 	return col.maxHeight > 1
 }
+
+// Writes a comma separating inputs on the center line
+// or spaces of the same width on other lines
+func writeSeparator(builder *strings.Builder, isCenter bool) {
+	if isCenter {
+		builder.WriteString(", ")
+	} else {
+		builder.WriteString("  ")
+	}
+}
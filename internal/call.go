@@ -0,0 +1,183 @@
+package internal
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// Prefix of all functions defined by this library
+const libraryPrefix = "github.com/Matej-Chmel/go-interview."
+
+// Prefix of all functions defined by the internal package
+const internalPrefix = "github.com/Matej-Chmel/go-interview/internal."
+
+// Information about a panic raised by a solution
+type PanicInfo struct {
+	Stack []string
+	Value any
+}
+
+// Returns a one line description of the panic
+func (p *PanicInfo) String() string {
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+// Result of a single call of a solution
+type CallResult[O any] struct {
+	Actual   O
+	Duration time.Duration
+	Err      error
+	Memory   *Memory
+	Modified bool
+	Panic    *PanicInfo
+	TimedOut bool
+}
+
+// Call of a solution prepared with copies of inputs of a test case.
+// Modified returns true if the call modified the copies.
+// It is nil if the inputs are not checked.
+type Prepared[O any] struct {
+	Call     func() (O, error)
+	Modified func() bool
+}
+
+// Settings of a single call of a solution
+type CallConfig struct {
+	Limit         time.Duration
+	MeasureMemory bool
+}
+
+// Calls f with a time limit and recovers from any panic.
+// If the limit is not positive, f is called directly. Otherwise f runs
+// in a separate goroutine that is abandoned if it does not finish
+// in time, because Go provides no way to stop it.
+func Call[O any](f func() (O, error), config CallConfig) (res CallResult[O]) {
+	if config.Limit <= 0 {
+		return measuredCall(f, config.MeasureMemory)
+	}
+
+	done := make(chan CallResult[O], 1)
+
+	go func() {
+		done <- measuredCall(f, config.MeasureMemory)
+	}()
+
+	timer := time.NewTimer(config.Limit)
+	defer timer.Stop()
+
+	select {
+	case res = <-done:
+	case <-timer.C:
+		res.TimedOut = true
+	}
+
+	return
+}
+
+// Calls the prepared solution like Call and checks whether it modified
+// its inputs. Inputs of a call that timed out are not checked,
+// because the solution may still be running.
+func CallPrepared[O any](p Prepared[O], config CallConfig) CallResult[O] {
+	res := Call(p.Call, config)
+
+	if p.Modified != nil && !res.TimedOut {
+		res.Modified = p.Modified()
+	}
+
+	return res
+}
+
+// Calls f, recovers from any panic and measures wall-clock time of the call.
+// If measureMemory is true, heap allocations made during the call are
+// counted the same way as testing.AllocsPerRun does.
+// Allocations of other goroutines running at the same time are included.
+func measuredCall[O any](
+	f func() (O, error), measureMemory bool,
+) (res CallResult[O]) {
+	var before, after runtime.MemStats
+
+	if measureMemory {
+		runtime.ReadMemStats(&before)
+	}
+
+	start := time.Now()
+	res.Actual, res.Panic, res.Err = SafeCall(f)
+	res.Duration = time.Since(start)
+
+	if measureMemory {
+		runtime.ReadMemStats(&after)
+		res.Memory = &Memory{
+			Allocs: after.Mallocs - before.Mallocs,
+			Bytes:  after.TotalAlloc - before.TotalAlloc,
+		}
+	}
+
+	return
+}
+
+// Calls f and recovers from any panic raised during the call.
+// If f panicked, the returned PanicInfo is not nil.
+func SafeCall[O any](f func() (O, error)) (res O, p *PanicInfo, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			p = &PanicInfo{Stack: trimStack(string(debug.Stack())), Value: v}
+		}
+	}()
+
+	res, err = f()
+	return
+}
+
+// Returns true if the function name belongs to the Go runtime
+func isRuntimeFrame(function string) bool {
+	return strings.HasPrefix(function, "runtime.") ||
+		strings.HasPrefix(function, "runtime/")
+}
+
+// Keeps only the frames between the panic and the call
+// made by this library, directly or by reflection.
+// Each frame is formatted on a single line.
+func trimStack(stack string) []string {
+	lines := strings.Split(strings.TrimSpace(stack), "\n")
+	res := make([]string, 0)
+	i := 1
+
+	// Skip frames of the recovery until the panic itself
+	for ; i < len(lines); i += 2 {
+		if strings.HasPrefix(lines[i], "panic(") {
+			i += 2
+			break
+		}
+	}
+
+	for ; i+1 < len(lines); i += 2 {
+		function := lines[i]
+
+		if paren := strings.LastIndexByte(function, '('); paren > 0 {
+			function = function[:paren]
+		}
+
+		if strings.HasPrefix(function, libraryPrefix) ||
+			strings.HasPrefix(function, internalPrefix) ||
+			strings.HasPrefix(function, "reflect.") {
+			break
+		}
+
+		if isRuntimeFrame(function) {
+			continue
+		}
+
+		location := strings.TrimSpace(lines[i+1])
+
+		if offset := strings.LastIndex(location, " +0x"); offset > 0 {
+			location = location[:offset]
+		}
+
+		res = append(res, fmt.Sprintf("at %s (%s)", function, location))
+	}
+
+	return res
+}
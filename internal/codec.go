@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"encoding/binary"
+	"math"
+	r "reflect"
+)
+
+// Reads values from bytes produced by the fuzzing engine.
+// Missing bytes are read as zeros, so any data can be decoded.
+type byteDecoder struct {
+	data []byte
+	pos  int
+}
+
+// Returns the next n bytes padded with zeros
+func (d *byteDecoder) next(n int) []byte {
+	res := make([]byte, n)
+	d.pos += copy(res, d.data[min(d.pos, len(d.data)):])
+	return res
+}
+
+// Returns the number of bytes left
+func (d *byteDecoder) remaining() int {
+	return max(len(d.data)-d.pos, 0)
+}
+
+// Reads a length that is never greater than the number of bytes left
+func (d *byteDecoder) readLength() int {
+	length, n := binary.Uvarint(d.data[min(d.pos, len(d.data)):])
+
+	if n <= 0 {
+		d.pos = len(d.data)
+		return 0
+	}
+
+	d.pos += n
+	return int(min(length, uint64(d.remaining())))
+}
+
+// Decodes data into inputs of a test case.
+// The second input is decoded only if isSingleInput is false.
+func DecodeInputs[I, I2 any](data []byte, isSingleInput bool) (I, I2) {
+	var input I
+	var input2 I2
+	d := &byteDecoder{data: data, pos: 0}
+	d.decode(r.ValueOf(&input).Elem())
+
+	if !isSingleInput {
+		d.decode(r.ValueOf(&input2).Elem())
+	}
+
+	return input, input2
+}
+
+// Decodes bytes into an addressable value
+func (d *byteDecoder) decode(val r.Value) {
+	val = settable(val)
+
+	switch val.Kind() {
+	case r.Bool:
+		val.SetBool(d.next(1)[0]&1 == 1)
+	case r.Int, r.Int8, r.Int16, r.Int32, r.Int64:
+		val.SetInt(signExtend(d.readUint(val.Type().Size()), val.Type().Size()))
+	case r.Uint, r.Uint8, r.Uint16, r.Uint32, r.Uint64, r.Uintptr:
+		val.SetUint(d.readUint(val.Type().Size()))
+	case r.Float32:
+		val.SetFloat(float64(math.Float32frombits(uint32(d.readUint(4)))))
+	case r.Float64:
+		val.SetFloat(math.Float64frombits(d.readUint(8)))
+	case r.Complex64:
+		re := math.Float32frombits(uint32(d.readUint(4)))
+		im := math.Float32frombits(uint32(d.readUint(4)))
+		val.SetComplex(complex(float64(re), float64(im)))
+	case r.Complex128:
+		re := math.Float64frombits(d.readUint(8))
+		im := math.Float64frombits(d.readUint(8))
+		val.SetComplex(complex(re, im))
+	case r.String:
+		val.SetString(string(d.next(d.readLength())))
+	case r.Slice:
+		length := d.readLength()
+		slice := r.MakeSlice(val.Type(), length, length)
+
+		for i := 0; i < length; i++ {
+			d.decode(slice.Index(i))
+		}
+
+		val.Set(slice)
+	case r.Array:
+		for i := 0; i < val.Len(); i++ {
+			d.decode(val.Index(i))
+		}
+	case r.Map:
+		length := d.readLength()
+		m := r.MakeMapWithSize(val.Type(), length)
+
+		for i := 0; i < length; i++ {
+			key := r.New(val.Type().Key()).Elem()
+			elem := r.New(val.Type().Elem()).Elem()
+			d.decode(key)
+			d.decode(elem)
+			m.SetMapIndex(key, elem)
+		}
+
+		val.Set(m)
+	case r.Pointer:
+		if d.next(1)[0]&1 == 0 {
+			return
+		}
+
+		ptr := r.New(val.Type().Elem())
+		d.decode(ptr.Elem())
+		val.Set(ptr)
+	case r.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			d.decode(val.Field(i))
+		}
+	}
+}
+
+// Reads an unsigned integer of size bytes in little endian
+func (d *byteDecoder) readUint(size uintptr) uint64 {
+	buf := make([]byte, 8)
+	copy(buf, d.next(int(size)))
+	return binary.LittleEndian.Uint64(buf)
+}
+
+// Interprets the lowest size bytes of n as a signed integer
+func signExtend(n uint64, size uintptr) int64 {
+	shift := 64 - 8*size
+	return int64(n<<shift) >> shift
+}
+
+// Encodes values into bytes that DecodeInputs decodes back
+func EncodeValues(values ...any) []byte {
+	res := make([]byte, 0)
+
+	for _, v := range values {
+		res = encode(res, r.ValueOf(&v).Elem().Elem())
+	}
+
+	return res
+}
+
+// Appends encoded val to data
+func encode(data []byte, val r.Value) []byte {
+	if !val.IsValid() {
+		return data
+	}
+
+	val = readable(val)
+
+	switch val.Kind() {
+	case r.Bool:
+		if val.Bool() {
+			return append(data, 1)
+		}
+
+		return append(data, 0)
+	case r.Int, r.Int8, r.Int16, r.Int32, r.Int64:
+		return appendUint(data, uint64(val.Int()), val.Type().Size())
+	case r.Uint, r.Uint8, r.Uint16, r.Uint32, r.Uint64, r.Uintptr:
+		return appendUint(data, val.Uint(), val.Type().Size())
+	case r.Float32:
+		return appendUint(data, uint64(math.Float32bits(float32(val.Float()))), 4)
+	case r.Float64:
+		return appendUint(data, math.Float64bits(val.Float()), 8)
+	case r.Complex64:
+		c := val.Complex()
+		data = appendUint(data, uint64(math.Float32bits(float32(real(c)))), 4)
+		return appendUint(data, uint64(math.Float32bits(float32(imag(c)))), 4)
+	case r.Complex128:
+		c := val.Complex()
+		data = appendUint(data, math.Float64bits(real(c)), 8)
+		return appendUint(data, math.Float64bits(imag(c)), 8)
+	case r.String:
+		data = binary.AppendUvarint(data, uint64(val.Len()))
+		return append(data, val.String()...)
+	case r.Slice:
+		data = binary.AppendUvarint(data, uint64(val.Len()))
+
+		for i := 0; i < val.Len(); i++ {
+			data = encode(data, val.Index(i))
+		}
+	case r.Array:
+		for i := 0; i < val.Len(); i++ {
+			data = encode(data, val.Index(i))
+		}
+	case r.Map:
+		data = binary.AppendUvarint(data, uint64(val.Len()))
+		iter := val.MapRange()
+
+		for iter.Next() {
+			data = encode(data, iter.Key())
+			data = encode(data, iter.Value())
+		}
+	case r.Pointer:
+		if val.IsNil() {
+			return append(data, 0)
+		}
+
+		return encode(append(data, 1), val.Elem())
+	case r.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			data = encode(data, val.Field(i))
+		}
+	}
+
+	return data
+}
+
+// Appends size lowest bytes of n in little endian
+func appendUint(data []byte, n uint64, size uintptr) []byte {
+	buf := binary.LittleEndian.AppendUint64(nil, n)
+	return append(data, buf[:size]...)
+}
+
+// Returns val in a form that can be read even if it is an unexported field
+func readable(val r.Value) r.Value {
+	if val.CanAddr() {
+		return settable(val)
+	}
+
+	res := r.New(val.Type()).Elem()
+	res.Set(val)
+	return res
+}
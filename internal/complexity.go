@@ -0,0 +1,236 @@
+package internal
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// Name of the class reported if the complexity cannot be estimated
+const UnknownComplexity = "O(?)"
+
+// Minimum number of input sizes needed to estimate complexity
+const MinComplexitySizes = 3
+
+// Total time of repeated calls after which the measurement
+// of one input size stops
+const complexityBudget = time.Millisecond
+
+// Maximum number of repeated calls for one input size
+const complexityMaxRuns = 100
+
+// Error of a simpler class relative to the smallest error
+// up to which the simpler class is preferred
+const complexityMargin = 1.5
+
+// Growth of times relative to their mean below which
+// the complexity is constant
+const complexityMinGrowth = 0.25
+
+// Complexity class with a function that models running time.
+// Degree is the power of n that dominates the model
+// with 3 standing for exponential growth.
+type complexityModel struct {
+	degree int
+	model  func(n float64) float64
+	name   string
+}
+
+// All complexity classes that are considered by the estimation
+// ordered from the simplest one
+var complexityModels = []complexityModel{
+	{0, func(n float64) float64 { return 1 }, "O(1)"},
+	{0, func(n float64) float64 { return math.Log2(n) }, "O(log n)"},
+	{1, func(n float64) float64 { return n }, "O(n)"},
+	{1, func(n float64) float64 { return n * math.Log2(n) }, "O(n log n)"},
+	{2, func(n float64) float64 { return n * n }, "O(n^2)"},
+	{3, func(n float64) float64 { return math.Exp2(n) }, "O(2^n)"},
+}
+
+// Estimated complexity class of a solution
+type Complexity struct {
+	// Name of the complexity class, for example O(n)
+	Class string
+	// Root mean square error of the fit relative to the mean time
+	Error float64
+	// Input sizes
+	Sizes []int
+	// Measured time for each input size
+	Times []time.Duration
+}
+
+// Estimates the power of n from the median slope of times between
+// all pairs of sizes on a log-log scale, which is robust to outliers.
+// Then fits times measured on inputs of given sizes to each complexity
+// class of that power using least squares and returns the simplest class
+// whose error is close to the smallest one. Classes other than O(1)
+// are skipped if the fitted times grow by only a small part of their mean.
+// Returns nil if there are fewer than MinComplexitySizes sizes.
+// The class O(?) is returned if no model fits.
+func EstimateComplexity(sizes []int, times []time.Duration) *Complexity {
+	if len(sizes) < MinComplexitySizes || len(sizes) != len(times) {
+		return nil
+	}
+
+	mean := 0.0
+
+	for _, t := range times {
+		mean += float64(t)
+	}
+
+	mean /= float64(len(times))
+	res := &Complexity{Class: "", Error: math.Inf(1), Sizes: sizes, Times: times}
+
+	if mean == 0 {
+		res.Class, res.Error = complexityModels[0].name, 0
+		return res
+	}
+
+	degree := estimateDegree(sizes, times)
+	errors := make([]float64, len(complexityModels))
+	best := math.Inf(1)
+
+	for i, m := range complexityModels {
+		fit := fitModel(m.model, sizes, times)
+		errors[i] = fit.error / mean
+
+		if m.degree != degree || (i > 0 && fit.growth/mean < complexityMinGrowth) {
+			errors[i] = math.Inf(1)
+		}
+
+		best = min(best, errors[i])
+	}
+
+	for i, m := range complexityModels {
+		if errors[i] <= best*complexityMargin {
+			res.Class, res.Error = m.name, errors[i]
+			break
+		}
+	}
+
+	if res.Class == "" {
+		res.Class = UnknownComplexity
+	}
+
+	return res
+}
+
+// Returns the power of n that dominates times measured on inputs
+// of given sizes. Slopes of at least 2.5 are considered exponential.
+func estimateDegree(sizes []int, times []time.Duration) int {
+	slopes := make([]float64, 0)
+
+	for i := range sizes {
+		for j := i + 1; j < len(sizes); j++ {
+			dn := math.Log(float64(max(sizes[j], 1))) - math.Log(float64(max(sizes[i], 1)))
+
+			if dn == 0 {
+				continue
+			}
+
+			dt := math.Log(float64(max(times[j], 1))) - math.Log(float64(max(times[i], 1)))
+			slopes = append(slopes, dt/dn)
+		}
+	}
+
+	if len(slopes) == 0 {
+		return 0
+	}
+
+	slices.Sort(slopes)
+	mid := len(slopes) / 2
+	slope := slopes[mid]
+
+	if len(slopes)%2 == 0 {
+		slope = (slopes[mid-1] + slopes[mid]) / 2
+	}
+
+	return min(max(int(math.Round(slope)), 0), 3)
+}
+
+// Fit of measured times to a + b * model(n)
+type complexityFit struct {
+	// Root mean square error of the fit
+	error float64
+	// Growth of the fitted time between the smallest and the largest size
+	growth float64
+}
+
+// Fits times to a + b * model(n) using least squares.
+// Models with a negative b don't fit and have an infinite error.
+func fitModel(
+	model func(n float64) float64, sizes []int, times []time.Duration,
+) complexityFit {
+	inf := complexityFit{error: math.Inf(1), growth: 0}
+	values := make([]float64, len(sizes))
+	meanG, meanT := 0.0, 0.0
+
+	for i, n := range sizes {
+		values[i] = model(float64(max(n, 1)))
+		meanG += values[i]
+		meanT += float64(times[i])
+	}
+
+	meanG /= float64(len(sizes))
+	meanT /= float64(len(sizes))
+
+	if math.IsInf(meanG, 0) || math.IsNaN(meanG) {
+		return inf
+	}
+
+	sumGT, sumGG := 0.0, 0.0
+
+	for i, g := range values {
+		sumGT += (g - meanG) * (float64(times[i]) - meanT)
+		sumGG += (g - meanG) * (g - meanG)
+	}
+
+	b := 0.0
+
+	if sumGG > 0 {
+		b = sumGT / sumGG
+	}
+
+	if b < 0 {
+		return inf
+	}
+
+	a, sumSquares := meanT-b*meanG, 0.0
+	low, high := values[0], values[0]
+
+	for i, g := range values {
+		diff := float64(times[i]) - a - b*g
+		sumSquares += diff * diff
+		low, high = min(low, g), max(high, g)
+	}
+
+	return complexityFit{
+		error:  math.Sqrt(sumSquares / float64(len(sizes))),
+		growth: b * (high - low),
+	}
+}
+
+// Calls run repeatedly until the time budget or maximum number
+// of runs is exhausted and returns the shortest measured time.
+// Returns false if any call failed.
+func MeasureSize(run func() (time.Duration, bool)) (time.Duration, bool) {
+	best, total := time.Duration(math.MaxInt64), time.Duration(0)
+
+	for i := 0; i < complexityMaxRuns && total < complexityBudget; i++ {
+		d, ok := run()
+
+		if !ok {
+			return 0, false
+		}
+
+		best = min(best, d)
+		total += d
+	}
+
+	return best, true
+}
+
+// Returns a string representation of the complexity
+func (c *Complexity) String() string {
+	return c.Class
+}
@@ -0,0 +1,221 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	r "reflect"
+)
+
+// Tolerance for comparing floating-point numbers.
+// Two numbers are equal if they differ by at most Abs
+// or by at most Rel times the greater of their magnitudes.
+type Tolerance struct {
+	Abs float64
+	Rel float64
+}
+
+// Decides which slices and arrays are compared regardless of order
+type Order int
+
+const (
+	// Elements are compared in order
+	Ordered Order = iota
+	// Order of the output and of slices stored in an output map is ignored
+	UnorderedTop
+	// Order of all slices and arrays at every level is ignored
+	UnorderedNested
+)
+
+// Returns a hint that tells apart two values that are not equal
+// even though their strings are. Values are shown in Go syntax
+// with full precision and if that doesn't tell them apart,
+// their types are shown. Returns an empty string if neither does.
+func DistinguishValues(actual, expected any) string {
+	a, e := fmt.Sprintf("%#v", actual), fmt.Sprintf("%#v", expected)
+
+	if a == e {
+		a, e = fmt.Sprintf("%T", actual), fmt.Sprintf("%T", expected)
+	}
+
+	if a == e {
+		return ""
+	}
+
+	return fmt.Sprintf("actual %s, expected %s", a, e)
+}
+
+// Settings of a comparison of outputs
+type EqualConfig struct {
+	Order     Order
+	Tolerance Tolerance
+}
+
+// Returns true if the tolerance allows any difference
+func (t Tolerance) IsSet() bool {
+	return t.Abs > 0 || t.Rel > 0
+}
+
+// Returns true if a and b have the same type and equal values.
+// Unlike reflect.DeepEqual, nil and empty slices or maps are equal,
+// NaN equals NaN and unexported fields are compared as well.
+// Floats and complex numbers may differ within the tolerance and
+// slices may be compared as multisets depending on the order.
+// Also returns the greatest difference between compared floats.
+func ConfigEqual[T any](a, b T, config EqualConfig) (bool, float64) {
+	e := equality{config: config, delta: 0}
+	ok := e.equal(r.ValueOf(&a).Elem(), r.ValueOf(&b).Elem(), true)
+	return ok, e.delta
+}
+
+// State of a recursive comparison
+type equality struct {
+	config EqualConfig
+	delta  float64
+}
+
+// Compares two values of the same type recursively.
+// Top is true for the output itself and values reachable from it
+// through pointers, interfaces and maps.
+func (e *equality) equal(a, b r.Value, top bool) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case r.Bool:
+		return a.Bool() == b.Bool()
+	case r.Int, r.Int8, r.Int16, r.Int32, r.Int64:
+		return a.Int() == b.Int()
+	case r.Uint, r.Uint8, r.Uint16, r.Uint32, r.Uint64, r.Uintptr:
+		return a.Uint() == b.Uint()
+	case r.Float32, r.Float64:
+		return e.floatEqual(a.Float(), b.Float())
+	case r.Complex64, r.Complex128:
+		return e.complexEqual(a.Complex(), b.Complex())
+	case r.String:
+		return a.String() == b.String()
+	case r.Slice, r.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		if e.isUnordered(top) {
+			return e.multisetEqual(a, b)
+		}
+
+		ok := true
+
+		for i := 0; i < a.Len(); i++ {
+			ok = e.equal(a.Index(i), b.Index(i), false) && ok
+		}
+
+		return ok
+	case r.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		ok := true
+		iter := a.MapRange()
+
+		for iter.Next() {
+			other := b.MapIndex(iter.Key())
+
+			if !other.IsValid() {
+				return false
+			}
+
+			ok = e.equal(iter.Value(), other, top) && ok
+		}
+
+		return ok
+	case r.Pointer, r.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+
+		return e.equal(a.Elem(), b.Elem(), top)
+	case r.Struct:
+		ok := true
+
+		for i := 0; i < a.NumField(); i++ {
+			ok = e.equal(a.Field(i), b.Field(i), false) && ok
+		}
+
+		return ok
+	case r.Func:
+		return a.IsNil() && b.IsNil()
+	default:
+		return a.Pointer() == b.Pointer()
+	}
+}
+
+// Returns true if elements of a slice at this level are compared
+// regardless of their order
+func (e *equality) isUnordered(top bool) bool {
+	return e.config.Order == UnorderedNested ||
+		(e.config.Order == UnorderedTop && top)
+}
+
+// Returns true if every element of a can be paired
+// with a distinct equal element of b
+func (e *equality) multisetEqual(a, b r.Value) bool {
+	used := make([]bool, b.Len())
+
+	for i := 0; i < a.Len(); i++ {
+		found := false
+
+		for j := 0; j < b.Len() && !found; j++ {
+			if used[j] {
+				continue
+			}
+
+			trial := equality{config: e.config, delta: 0}
+
+			if trial.equal(a.Index(i), b.Index(j), false) {
+				e.delta = max(e.delta, trial.delta)
+				found, used[j] = true, true
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if complex numbers are equal within the tolerance
+func (e *equality) complexEqual(a, b complex128) bool {
+	if a == b || (cmplx.IsNaN(a) && cmplx.IsNaN(b)) {
+		return true
+	}
+
+	return e.withinTolerance(cmplx.Abs(a-b), max(cmplx.Abs(a), cmplx.Abs(b)))
+}
+
+// Returns true if floats are equal within the tolerance or both are NaN
+func (e *equality) floatEqual(a, b float64) bool {
+	if a == b || (math.IsNaN(a) && math.IsNaN(b)) {
+		return true
+	}
+
+	return e.withinTolerance(math.Abs(a-b), max(math.Abs(a), math.Abs(b)))
+}
+
+// Records the difference and checks it against the tolerance
+func (e *equality) withinTolerance(diff, magnitude float64) bool {
+	if math.IsNaN(diff) {
+		diff = math.Inf(1)
+	}
+
+	tolerance := e.config.Tolerance
+	e.delta = max(e.delta, diff)
+	return diff <= tolerance.Abs || diff <= tolerance.Rel*magnitude
+}
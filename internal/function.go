@@ -3,29 +3,35 @@ package internal
 import (
 	"reflect"
 	"runtime"
-	"sort"
 	"strings"
 )
 
-// Executes all solution functions from a map
+// Executes all solution functions from a registry on a pool
+// and orders the receipts. The order doesn't depend on the pool.
 func ExecuteSolutions[T any](
-	solutions map[string]T, target func(T) Receipt,
+	solutions *Registry[T], target func(string, T) Receipt,
+	order SolutionOrder, pool *Pool,
 ) (res ReceiptSlice) {
-	i := 0
-	res.Receipts = make([]Receipt, len(solutions))
+	names := solutions.Names(false)
+	res.Receipts = make([]Receipt, len(names))
 
-	for _, sol := range solutions {
-		res.Receipts[i] = target(sol)
-		i++
-	}
+	pool.Run(len(names), func(i int) {
+		sol, _ := solutions.Get(names[i])
+		res.Receipts[i] = target(names[i], sol)
+	})
 
-	sort.Sort(&res)
+	res.Sort(order)
 	return
 }
 
-// Returns name of f without package information
+// Returns name of f without package information.
+// Type parameters of generic functions are removed, methods keep
+// the name of their receiver type and closures keep the name
+// of their enclosing function.
 func GetFunctionName(f interface{}) string {
 	name := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
-	tokens := strings.Split(name, ".")
-	return tokens[len(tokens)-1]
+	name = strings.TrimSuffix(strings.ReplaceAll(name, "[...]", ""), "-fm")
+	name = name[strings.LastIndexByte(name, '/')+1:]
+	name = name[strings.IndexByte(name, '.')+1:]
+	return strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
 }
@@ -1,9 +1,12 @@
 package internal
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	r "reflect"
+	"strconv"
 	"strings"
 
 	nio "github.com/Matej-Chmel/go-number-io"
@@ -75,3 +78,143 @@ func ReadData[T any](relPath string) (T, error) {
 	defer file.Close()
 	return nio.Read[T](file)
 }
+
+// Reads a value of type t from a file on relative path relPath.
+// The type must be a number, a bool or a 1D, 2D or 3D slice of them.
+func ReadValue(relPath string, t r.Type) (r.Value, error) {
+	depth, elem := 0, t
+
+	for ; elem.Kind() == r.Slice; depth++ {
+		elem = elem.Elem()
+	}
+
+	var data any
+	var err error
+
+	switch elem.Kind() {
+	case r.Bool:
+		data, err = readDepth[bool](relPath, depth)
+	case r.Int, r.Int8, r.Int16, r.Int32, r.Int64:
+		data, err = readDepth[int64](relPath, depth)
+	case r.Uint, r.Uint8, r.Uint16, r.Uint32, r.Uint64, r.Uintptr:
+		data, err = readDepth[uint64](relPath, depth)
+	case r.Float32, r.Float64:
+		data, err = readDepth[float64](relPath, depth)
+	default:
+		err = fmt.Errorf("cannot read value of type %s", t)
+	}
+
+	if err != nil {
+		return r.Value{}, err
+	}
+
+	return convertSlice(r.ValueOf(data), t), nil
+}
+
+// Reads E nested in depth slices from a file on relative path relPath
+func readDepth[E any](relPath string, depth int) (any, error) {
+	switch depth {
+	case 0:
+		return ReadData[E](relPath)
+	case 1:
+		return ReadData[[]E](relPath)
+	case 2:
+		return ReadData[[][]E](relPath)
+	case 3:
+		return ReadData[[][][]E](relPath)
+	}
+
+	return nil, fmt.Errorf("cannot read %d nested slices", depth)
+}
+
+// Converts val and all its elements to type t
+func convertSlice(val r.Value, t r.Type) r.Value {
+	if val.Kind() != r.Slice {
+		return val.Convert(t)
+	}
+
+	res := r.MakeSlice(t, val.Len(), val.Len())
+
+	for i := 0; i < val.Len(); i++ {
+		res.Index(i).Set(convertSlice(val.Index(i), t.Elem()))
+	}
+
+	return res
+}
+
+// Formats a number, a bool or a 1D, 2D or 3D slice of them
+// in the format accepted by ReadData
+func FormatData(data any) (string, error) {
+	return formatData(r.ValueOf(data), 0)
+}
+
+// Formats val nested in depth slices
+func formatData(val r.Value, depth int) (string, error) {
+	switch val.Kind() {
+	case r.Bool:
+		if val.Bool() {
+			return "1", nil
+		}
+
+		return "0", nil
+	case r.Int, r.Int8, r.Int16, r.Int32, r.Int64:
+		return strconv.FormatInt(val.Int(), 10), nil
+	case r.Uint, r.Uint8, r.Uint16, r.Uint32, r.Uint64, r.Uintptr:
+		return strconv.FormatUint(val.Uint(), 10), nil
+	case r.Float32, r.Float64:
+		return strconv.FormatFloat(val.Float(), 'f', -1, val.Type().Bits()), nil
+	case r.Slice, r.Array:
+		if depth >= 3 {
+			break
+		}
+
+		separators := []string{" ", "\n", "\n\n"}
+		parts := make([]string, val.Len())
+
+		for i := range parts {
+			part, err := formatData(val.Index(i), depth+1)
+
+			if err != nil {
+				return "", err
+			}
+
+			parts[i] = part
+		}
+
+		separator := separators[min(sliceDepth(val.Type().Elem()), 2)]
+		return strings.Join(parts, separator), nil
+	}
+
+	return "", fmt.Errorf("cannot save value of type %s", val.Type())
+}
+
+// Returns the number of nested slice or array types in t
+func sliceDepth(t r.Type) int {
+	if k := t.Kind(); k == r.Slice || k == r.Array {
+		return 1 + sliceDepth(t.Elem())
+	}
+
+	return 0
+}
+
+// Writes data to a file on relative path relPath
+// in the format accepted by ReadData
+func WriteData(relPath string, data any) error {
+	content, err := FormatData(data)
+
+	if err != nil {
+		return err
+	}
+
+	filePath, err := ProgramPathJoin(relPath)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, []byte(content+"\n"), 0o644)
+}
@@ -43,9 +43,10 @@ func NewLinesIterator(s string) *LineIterator {
 	}
 }
 
-// Returns the next available line
+// Returns the next available line or spaces
+// above and below the lines
 func (it *LineIterator) Next(i int) string {
-	if i < it.startAt {
+	if i < it.startAt || it.current >= it.height {
 		return it.skipString
 	}
 
@@ -54,18 +55,32 @@ func (it *LineIterator) Next(i int) string {
 	return res
 }
 
-// Collection of iterators for inputs and outputs
+// Collection of iterators for inputs and outputs.
+// Mismatch replaces != between actual and expected output if not empty.
+// Suffix is written at the end of the center line.
+// If Steps is true, each line is a separate step with its own arrow
+// and the mismatch is written on line Diverged instead of the center.
 type IteratorCollection struct {
+	Diverged  int
+	Errored   bool
+	Mismatch  string
+	Steps     bool
+	Suffix    string
 	actual    *LineIterator
 	expected  *LineIterator
 	input     *LineIterator
 	input2    *LineIterator
+	more      []*LineIterator
 	ok        bool
 	maxHeight int
+	timedOut  bool
 }
 
 // Constructs a new IteratorCollection
-func NewIteratorCollection(actual, expected string, input1 string, input2 *string) *IteratorCollection {
+func NewIteratorCollection(
+	actual, expected string, input1 string, input2 *string,
+	ok, timedOut bool,
+) *IteratorCollection {
 	var i2 *LineIterator = nil
 
 	if input2 != nil {
@@ -73,29 +88,68 @@ func NewIteratorCollection(actual, expected string, input1 string, input2 *strin
 	}
 
 	c := &IteratorCollection{
+		Diverged:  0,
+		Errored:   false,
+		Mismatch:  "",
+		Steps:     false,
+		Suffix:    "",
 		actual:    NewLinesIterator(actual),
 		expected:  NewLinesIterator(expected),
 		input:     NewLinesIterator(input1),
 		input2:    i2,
-		ok:        actual == expected,
+		more:      nil,
+		ok:        ok,
 		maxHeight: 0,
+		timedOut:  timedOut,
 	}
 	c.calculateSkip()
 	return c
 }
 
+// Adds iterators for inputs following the second one
+func (c *IteratorCollection) AddInputs(inputs []string) {
+	for _, input := range inputs {
+		c.more = append(c.more, NewLinesIterator(input))
+	}
+
+	c.calculateSkip()
+}
+
+// Returns the status marker written at the start of the first line
+func (c *IteratorCollection) Marker() string {
+	if c.timedOut {
+		return "(TLE)"
+	}
+
+	if c.ok {
+		return "(OK)"
+	}
+
+	if c.Errored {
+		return "(ERR)"
+	}
+
+	return "(  )"
+}
+
 // Calculates the first non-empty line for all iterators
 func (c *IteratorCollection) calculateSkip() {
-	c.maxHeight = max(c.actual.height, c.expected.height, c.input.height)
+	iters := []*LineIterator{c.actual, c.expected, c.input}
 
 	if c.input2 != nil {
-		c.maxHeight = max(c.maxHeight, c.input2.height)
-		c.input2.calculateSkip(c.maxHeight)
+		iters = append(iters, c.input2)
 	}
 
-	c.actual.calculateSkip(c.maxHeight)
-	c.expected.calculateSkip(c.maxHeight)
-	c.input.calculateSkip(c.maxHeight)
+	iters = append(iters, c.more...)
+	c.maxHeight = 0
+
+	for _, it := range iters {
+		c.maxHeight = max(c.maxHeight, it.height)
+	}
+
+	for _, it := range iters {
+		it.calculateSkip(c.maxHeight)
+	}
 }
 
 // Writes next available line from iterator for the actual output to b
@@ -118,6 +172,12 @@ func (c *IteratorCollection) WriteInput2(b *strings.Builder, i int) {
 	c.writeString(b, c.input2, i, 0)
 }
 
+// Writes next available line from iterator for the input
+// at position j following the second input to b
+func (c *IteratorCollection) WriteMoreInput(b *strings.Builder, j, i int) {
+	c.writeString(b, c.more[j], i, 0)
+}
+
 // Write a line and its right padding unless the string is the last one
 // on the current line in the string builder b
 func (c *IteratorCollection) writeString(
@@ -0,0 +1,30 @@
+package internal
+
+import "fmt"
+
+// Heap memory allocated during one or more calls of a solution
+type Memory struct {
+	Allocs uint64
+	Bytes  uint64
+}
+
+// Adds the allocations of o to m
+func (m *Memory) Add(o *Memory) {
+	m.Allocs += o.Allocs
+	m.Bytes += o.Bytes
+}
+
+// Returns a new Memory with allocations averaged over runs
+func (m *Memory) Average(runs int) *Memory {
+	if runs <= 1 {
+		return &Memory{Allocs: m.Allocs, Bytes: m.Bytes}
+	}
+
+	n := uint64(runs)
+	return &Memory{Allocs: m.Allocs / n, Bytes: m.Bytes / n}
+}
+
+// Returns a string representation of the allocations
+func (m *Memory) String() string {
+	return fmt.Sprintf("%d B, %d allocs", m.Bytes, m.Allocs)
+}
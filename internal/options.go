@@ -1,26 +1,195 @@
 package internal
 
-import at "github.com/Matej-Chmel/go-any-to-string"
+import (
+	"time"
+
+	at "github.com/Matej-Chmel/go-any-to-string"
+)
 
 // Embeds Options for any-to-string library.
 // Provides methods for changing options by both
 // Interview and Interview2 structs.
 type EmbeddedOptions struct {
-	options *at.Options
+	caseTimeout     time.Duration
+	concurrencyRuns int
+	concurrentCases bool
+	detectMutation  bool
+	floatTolerance  Tolerance
+	measureMemory   bool
+	options         *at.Options
+	pool            *Pool
+	solutionOrder   SolutionOrder
+	solutionTimeout time.Duration
+	timingRuns      int
 }
 
 // Constructs new EmbeddedOptions
-func NewEmbeddedOptions() EmbeddedOptions {
-	return EmbeddedOptions{
-		options: at.NewOptions(),
+func NewEmbeddedOptions() *EmbeddedOptions {
+	return &EmbeddedOptions{
+		caseTimeout:     0,
+		concurrencyRuns: 0,
+		concurrentCases: false,
+		detectMutation:  false,
+		floatTolerance:  Tolerance{Abs: 0, Rel: 0},
+		measureMemory:   false,
+		options:         at.NewOptions(),
+		pool:            NewPool(1),
+		solutionOrder:   Alphabetical,
+		solutionTimeout: 0,
+		timingRuns:      0,
 	}
 }
 
+// Returns true if cases of one solution run concurrently
+func (e *EmbeddedOptions) AreCasesConcurrent() bool {
+	return e.concurrentCases
+}
+
+// Enables checking of solutions that use goroutines.
+// Each case is repeated the given number of times with GOMAXPROCS
+// cycling through powers of two up to the number of CPUs.
+// A case fails if a run leaves goroutines running after it returned
+// or if the runs return different outputs.
+func (e *EmbeddedOptions) CheckConcurrency(runs int) {
+	e.concurrencyRuns = max(runs, 1)
+}
+
+// Enables strict mode in which every case where a solution modified
+// its inputs is marked as failed. Solutions added as in-place
+// solutions are not checked.
+func (e *EmbeddedOptions) DetectInputMutation() {
+	e.detectMutation = true
+}
+
+// Returns the time limit for a single test case
+func (e *EmbeddedOptions) GetCaseTimeout() time.Duration {
+	return e.caseTimeout
+}
+
+// Returns the number of repeated runs per test case
+// when checking solutions that use goroutines.
+// Zero means that the checking is disabled.
+func (e *EmbeddedOptions) GetConcurrencyRuns() int {
+	return e.concurrencyRuns
+}
+
+// Returns the tolerance for comparing floats in outputs
+func (e *EmbeddedOptions) GetFloatTolerance() Tolerance {
+	return e.floatTolerance
+}
+
 // Returns a pointer to the underlying options
 func (e *EmbeddedOptions) GetOptions() *at.Options {
 	return e.options
 }
 
+// Returns the pool on which solutions and cases run
+func (e *EmbeddedOptions) GetPool() *Pool {
+	return e.pool
+}
+
+// Returns the order in which solutions are shown
+func (e *EmbeddedOptions) GetSolutionOrder() SolutionOrder {
+	return e.solutionOrder
+}
+
+// Returns the time limit for all test cases of one solution
+func (e *EmbeddedOptions) GetSolutionTimeout() time.Duration {
+	return e.solutionTimeout
+}
+
+// Returns the number of timed runs per test case.
+// Zero means that the timing is disabled.
+func (e *EmbeddedOptions) GetTimingRuns() int {
+	return e.timingRuns
+}
+
+// Returns true if modifications of inputs by solutions are detected
+func (e *EmbeddedOptions) IsInputMutationDetected() bool {
+	return e.detectMutation
+}
+
+// Returns true if heap allocations of solutions are measured
+func (e *EmbeddedOptions) IsMemoryMeasured() bool {
+	return e.measureMemory
+}
+
+// Enables measuring of heap allocations made by every solution
+// on every case. Copying of inputs before the call is not included.
+// The output then contains allocations for each case
+// and a table of solutions ordered by allocated bytes.
+// Solutions called by reflection, such as tuple solutions
+// and solutions of InterviewN, are not measured and are listed at the end of the table.
+func (e *EmbeddedOptions) MeasureMemory() {
+	e.measureMemory = true
+}
+
+// Enables measuring of wall-clock time for every solution on every case.
+// Each case is run the given number of times and if runs > 1,
+// both minimum and median times are reported.
+// The output then contains times for each case and a ranking of solutions.
+// Times of solutions called by reflection, such as tuple solutions
+// and solutions of InterviewN, include the cost of the reflective call.
+func (e *EmbeddedOptions) MeasureTime(runs int) {
+	e.timingRuns = max(runs, 1)
+}
+
+// Shows solutions by their names in alphabetical order.
+// This is the default order.
+func (e *EmbeddedOptions) OrderByName() {
+	e.solutionOrder = Alphabetical
+}
+
+// Shows solutions with a greater share of passed cases first.
+// Solutions with the same share keep the order in which they were added.
+func (e *EmbeddedOptions) OrderByPassRate() {
+	e.solutionOrder = ByPassRate
+}
+
+// Shows solutions in the order in which they were added
+func (e *EmbeddedOptions) OrderByRegistration() {
+	e.solutionOrder = RegistrationOrder
+}
+
+// Shows faster solutions first. Solutions are compared by their
+// measured times if the timing is enabled and by the time spent
+// on all cases otherwise. Solutions that panicked or timed out
+// on some cases are shown after the others.
+func (e *EmbeddedOptions) OrderBySpeed() {
+	e.solutionOrder = BySpeed
+}
+
+// Runs cases of each solution concurrently on the pool
+// set by RunConcurrently. The order of cases in the output is kept.
+func (e *EmbeddedOptions) RunCasesConcurrently() {
+	e.concurrentCases = true
+}
+
+// Runs solutions concurrently on at most workers goroutines.
+// The order of solutions in the output is kept.
+// Values below 2 make all runs sequential.
+// Runs stay sequential while time, memory or complexity is measured
+// and while solutions using goroutines are checked.
+func (e *EmbeddedOptions) RunConcurrently(workers int) {
+	e.pool = NewPool(workers)
+}
+
+// Sets the time limit for a single test case.
+// A case that runs longer is reported as TLE.
+// Zero or negative value disables the limit.
+func (e *EmbeddedOptions) SetCaseTimeout(limit time.Duration) {
+	e.caseTimeout = limit
+}
+
+// Sets the tolerance for comparing floats and complex numbers in outputs,
+// including those inside slices, arrays, maps and struct fields.
+// Two numbers are equal if they differ by at most abs or by at most
+// rel times the greater of their magnitudes. The greatest difference
+// is shown next to each failed case. Zero values require exact equality.
+func (e *EmbeddedOptions) SetFloatTolerance(abs, rel float64) {
+	e.floatTolerance = Tolerance{Abs: max(abs, 0), Rel: max(rel, 0)}
+}
+
 // Sets the underlying options.
 // If nil is passed, options are set to a default value.
 func (e *EmbeddedOptions) SetOptions(val *at.Options) {
@@ -31,6 +200,13 @@ func (e *EmbeddedOptions) SetOptions(val *at.Options) {
 	}
 }
 
+// Sets the time limit for all test cases of one solution.
+// Once the limit is exhausted, the remaining cases are reported as TLE.
+// Zero or negative value disables the limit.
+func (e *EmbeddedOptions) SetSolutionTimeout(limit time.Duration) {
+	e.solutionTimeout = limit
+}
+
 // Changes options so that byte, uint8, rune and int32 are all
 // printed as characters
 func (e *EmbeddedOptions) ShowBytesAsString() {
@@ -0,0 +1,43 @@
+package internal
+
+import "sync"
+
+// Bounded pool of goroutines that can be shared by nested runs,
+// such as solutions and their cases. The calling goroutine
+// counts as one of the workers.
+type Pool struct {
+	slots chan struct{}
+}
+
+// Constructs a Pool that runs at most workers tasks at the same time.
+// Values below 2 make the pool run tasks one after another.
+func NewPool(workers int) *Pool {
+	return &Pool{slots: make(chan struct{}, max(workers-1, 0))}
+}
+
+// Calls task for every index from 0 to n - 1 and waits until all calls
+// return. A task runs in a new goroutine if a worker is free
+// and in the calling goroutine otherwise.
+func (p *Pool) Run(n int, task func(i int)) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		select {
+		case p.slots <- struct{}{}:
+			wg.Add(1)
+
+			go func(i int) {
+				defer func() {
+					<-p.slots
+					wg.Done()
+				}()
+
+				task(i)
+			}(i)
+		default:
+			task(i)
+		}
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"runtime"
+	"time"
+)
+
+// Time given to goroutines started by a call to finish after it returned
+const leakTimeout = 20 * time.Millisecond
+
+// Result of repeating a call under varying GOMAXPROCS.
+// Distinct is the number of different results of all runs
+// and Leaked is the greatest number of goroutines left running by a run.
+type RaceCheck struct {
+	Distinct int
+	Leaked   int
+}
+
+// Repeats call the given number of times with GOMAXPROCS cycling through
+// powers of two up to the number of CPUs. Results of all runs including
+// the first one are grouped by equal. Repetition stops early if the call
+// times out, because the solution may still be running.
+func CheckRaces[O any](
+	first CallResult[O], call func() CallResult[O], runs int,
+	equal func(a, b CallResult[O]) bool,
+) RaceCheck {
+	procs := procsSequence()
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	distinct := []CallResult[O]{first}
+	res := RaceCheck{Distinct: 0, Leaked: 0}
+
+	for i := 0; i < runs; i++ {
+		runtime.GOMAXPROCS(procs[i%len(procs)])
+		before := runtime.NumGoroutine()
+		run := call()
+
+		if run.TimedOut {
+			break
+		}
+
+		res.Leaked = max(res.Leaked, leakedGoroutines(before))
+
+		if !containsResult(distinct, run, equal) {
+			distinct = append(distinct, run)
+		}
+	}
+
+	res.Distinct = len(distinct)
+	return res
+}
+
+// Returns true if results contain a result equal to res
+func containsResult[O any](
+	results []CallResult[O], res CallResult[O],
+	equal func(a, b CallResult[O]) bool,
+) bool {
+	for _, r := range results {
+		if equal(r, res) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns the number of goroutines above before that are still alive
+// after they were given a short time to finish
+func leakedGoroutines(before int) int {
+	deadline := time.Now().Add(leakTimeout)
+
+	for {
+		leaked := runtime.NumGoroutine() - before
+
+		if leaked <= 0 {
+			return 0
+		}
+
+		if time.Now().After(deadline) {
+			return leaked
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Returns values of GOMAXPROCS from 1 up to the number of CPUs
+// doubling at each step. At least two values are returned.
+func procsSequence() []int {
+	n := max(runtime.NumCPU(), 2)
+	res := make([]int, 0)
+
+	for p := 1; p < n; p *= 2 {
+		res = append(res, p)
+	}
+
+	return append(res, n)
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"math/rand/v2"
+	r "reflect"
+	"unsafe"
+)
+
+// Letters used by randomly generated strings
+const randomLetters = "abcdefghijklmnopqrstuvwxyz"
+
+// Generates a random value of type T. Numbers lie in range [-size, size],
+// slices, maps and strings have at most size elements.
+// The limit is halved for values nested in pointers, slices and maps
+// and pointers nested deeper than the limit allows are nil,
+// so that recursive types such as trees stay finite.
+// Structs are filled field by field, including unexported fields.
+// Types that cannot be generated are left with their zero value.
+func RandomValue[T any](rng *rand.Rand, size int) T {
+	var res T
+	val := r.ValueOf(&res).Elem()
+	fillRandom(rng, val, max(size, 0), max(size, 0))
+	return res
+}
+
+// Converts a reflected value back to T
+func FromValue[T any](val r.Value) T {
+	var res T
+	r.ValueOf(&res).Elem().Set(settable(val))
+	return res
+}
+
+// Returns a value that can be set even if it is an unexported field
+func settable(val r.Value) r.Value {
+	if val.CanSet() || !val.CanAddr() {
+		return val
+	}
+
+	return r.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem()
+}
+
+// Fills an addressable value with random data. Numbers lie in range
+// [-size, size] and budget limits the number of elements
+// of nested values, which get half of it.
+func fillRandom(rng *rand.Rand, val r.Value, size, budget int) {
+	val = settable(val)
+
+	switch val.Kind() {
+	case r.Bool:
+		val.SetBool(rng.IntN(2) == 1)
+	case r.Int, r.Int8, r.Int16, r.Int32, r.Int64:
+		n := rng.Int64N(int64(2*size+1)) - int64(size)
+
+		if val.OverflowInt(n) {
+			n = 0
+		}
+
+		val.SetInt(n)
+	case r.Uint, r.Uint8, r.Uint16, r.Uint32, r.Uint64, r.Uintptr:
+		n := rng.Uint64N(uint64(size + 1))
+
+		if val.OverflowUint(n) {
+			n = 0
+		}
+
+		val.SetUint(n)
+	case r.Float32, r.Float64:
+		val.SetFloat((rng.Float64()*2 - 1) * float64(size))
+	case r.Complex64, r.Complex128:
+		re := (rng.Float64()*2 - 1) * float64(size)
+		im := (rng.Float64()*2 - 1) * float64(size)
+		val.SetComplex(complex(re, im))
+	case r.String:
+		data := make([]byte, rng.IntN(budget+1))
+
+		for i := range data {
+			data[i] = randomLetters[rng.IntN(len(randomLetters))]
+		}
+
+		val.SetString(string(data))
+	case r.Slice:
+		length := rng.IntN(budget + 1)
+		slice := r.MakeSlice(val.Type(), length, length)
+
+		for i := 0; i < length; i++ {
+			fillRandom(rng, slice.Index(i), size, budget/2)
+		}
+
+		val.Set(slice)
+	case r.Array:
+		for i := 0; i < val.Len(); i++ {
+			fillRandom(rng, val.Index(i), size, budget)
+		}
+	case r.Map:
+		length := rng.IntN(budget + 1)
+		m := r.MakeMapWithSize(val.Type(), length)
+
+		for i := 0; i < length; i++ {
+			key := r.New(val.Type().Key()).Elem()
+			elem := r.New(val.Type().Elem()).Elem()
+			fillRandom(rng, key, size, budget/2)
+			fillRandom(rng, elem, size, budget/2)
+			m.SetMapIndex(key, elem)
+		}
+
+		val.Set(m)
+	case r.Pointer:
+		if budget == 0 || rng.IntN(budget+2) == 0 {
+			return
+		}
+
+		ptr := r.New(val.Type().Elem())
+		fillRandom(rng, ptr.Elem(), size, budget/2)
+		val.Set(ptr)
+	case r.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			fillRandom(rng, val.Field(i), size, budget)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Outcome of random testing of one solution
+type RandomReceipt struct {
+	// Minimal failing case or nil if all random inputs passed
+	Failure *ReceiptLine
+	// Index of the first failing random input starting from 1
+	FailedAt int
+	// Minimal failing inputs
+	Inputs    []any
+	Name      string
+	Runs      int
+	SaveError error
+	Saved     []string
+	Seed      uint64
+	Shrinks   int
+}
+
+// Writes itself to builder
+func (s *RandomReceipt) ContinueBuild(builder *strings.Builder) {
+	builder.WriteString(s.Name)
+	builder.WriteRune('\n')
+	builder.WriteString(strings.Repeat("=", len(s.Name)))
+	builder.WriteRune('\n')
+
+	if s.Failure == nil {
+		builder.WriteString(fmt.Sprintf(
+			"(OK) %d random inputs passed (seed %d)", s.Runs, s.Seed))
+		return
+	}
+
+	s.Failure.ContinueBuild(builder)
+	builder.WriteString(fmt.Sprintf(
+		"\nFailed on random input %d of %d (seed %d), shrunk in %d steps",
+		s.FailedAt, s.Runs, s.Seed, s.Shrinks))
+
+	if s.SaveError != nil {
+		builder.WriteString("\nCould not save the case: ")
+		builder.WriteString(s.SaveError.Error())
+	} else if len(s.Saved) > 0 {
+		builder.WriteString("\nSaved to ")
+		builder.WriteString(strings.Join(s.Saved, ", "))
+	}
+}
+
+// Slice of RandomReceipts
+type RandomReceiptSlice struct {
+	Receipts []RandomReceipt
+}
+
+// Writes itself to builder
+func (s *RandomReceiptSlice) ContinueBuild(builder *strings.Builder) {
+	for i := range s.Receipts {
+		if i > 0 {
+			builder.WriteString("\n\n")
+		}
+
+		s.Receipts[i].ContinueBuild(builder)
+	}
+}
+
+// Returns a string representation of the slice
+func (s RandomReceiptSlice) String() string {
+	var builder strings.Builder
+	s.ContinueBuild(&builder)
+	return builder.String()
+}
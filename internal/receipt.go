@@ -1,21 +1,128 @@
 package internal
 
 import (
+	"cmp"
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 )
 
 // Output information for all test cases under one solution name
 type Receipt struct {
-	Lines []*ReceiptLine
-	Name  string
+	Complexity       *Complexity
+	Description      string
+	Elapsed          time.Duration
+	Leaking          int
+	Lines            []*ReceiptLine
+	Memory           *Memory
+	MemorySkipped    bool
+	Name             string
+	Nondeterministic int
+	Panicked         int
+	Passed           int
+	TimedOut         int
+	Timed            int
+	Timing           *Timing
+	Wrong            int
+}
+
+// Constructs a Receipt and counts passed, wrong and panicked lines.
+// Lines with leaked goroutines or different outputs over repeated runs
+// are counted separately as well.
+func NewReceipt(name string, lines []*ReceiptLine) (r Receipt) {
+	r.Lines = lines
+	r.Name = name
+
+	for _, l := range lines {
+		if l.Leaked > 0 {
+			r.Leaking++
+		}
+
+		if l.Distinct > 1 {
+			r.Nondeterministic++
+		}
+
+		if l.Memory != nil {
+			if r.Memory == nil {
+				r.Memory = &Memory{Allocs: 0, Bytes: 0}
+			}
+
+			r.Memory.Add(l.Memory)
+		}
+
+		if l.Timing != nil {
+			if r.Timing == nil {
+				r.Timing = &Timing{Median: 0, Min: 0, Runs: 0}
+			}
+
+			r.Timing.Add(l.Timing)
+			r.Timed++
+		}
+
+		if l.TimedOut {
+			r.TimedOut++
+		} else if l.Panic != nil {
+			r.Panicked++
+		} else if l.IsOk() {
+			r.Passed++
+		} else {
+			r.Wrong++
+		}
+	}
+
+	return
+}
+
+// Returns true if the speed of the solution was measured on all cases.
+// Cases that panicked or timed out are not measured.
+func (s *Receipt) isComplete() bool {
+	if s.Timing != nil {
+		return s.Timed == len(s.Lines)
+	}
+
+	return s.Panicked == 0 && s.TimedOut == 0
+}
+
+// Returns the share of passed cases
+func (s *Receipt) passRate() float64 {
+	if len(s.Lines) == 0 {
+		return 0
+	}
+
+	return float64(s.Passed) / float64(len(s.Lines))
+}
+
+// Returns the sum of measured median times if the timing was enabled
+// and the time spent on all cases otherwise
+func (s *Receipt) speed() time.Duration {
+	if s.Timing != nil {
+		return s.Timing.Median
+	}
+
+	return s.Elapsed
 }
 
 // Writes itself to builder
+// The header contains the estimated complexity if available
+// and is followed by the description of the solution if set.
 // Each multi-line test case is separated by double newline
 func (s *Receipt) ContinueBuild(builder *strings.Builder) {
-	builder.WriteString(s.Name)
+	header := s.Name
+
+	if s.Complexity != nil {
+		header = fmt.Sprintf("%s ~ %s", s.Name, s.Complexity)
+	}
+
+	builder.WriteString(header)
 	builder.WriteRune('\n')
-	builder.WriteString(strings.Repeat("=", len(s.Name)))
+	builder.WriteString(strings.Repeat("=", len(header)))
+
+	if s.Description != "" {
+		builder.WriteRune('\n')
+		builder.WriteString(s.Description)
+	}
+
 	isMultiLine := false
 
 	for _, l := range s.Lines {
@@ -29,12 +136,45 @@ func (s *Receipt) ContinueBuild(builder *strings.Builder) {
 	}
 }
 
-// Output information about a single test case
+// Output information about a single test case.
+// Mismatch is written between actual and expected output
+// of a failed case. Empty Mismatch stands for !=.
+// Ok is the verdict of comparing the actual output with the expected one.
+// If the case accepts multiple outputs, Matched is the position
+// of the matching output starting from 1 out of Accepted outputs.
+// Errored is true if the solution returned an error.
+// Hint tells apart actual and expected outputs that differ
+// even though their strings are equal.
+// Modified is true if the solution modified its inputs.
+// Distinct is the number of different outputs over runs repeated
+// under varying GOMAXPROCS and Leaked is the greatest number
+// of goroutines left running by a run.
+// MoreInputs holds inputs following the second one.
+// Steps is true if each line of the input and outputs is a separate step
+// of a design problem. Diverged is then the first step whose actual output
+// differs from the expected one.
 type ReceiptLine struct {
-	Actual   string
-	Expected string
-	Input    string
-	Input2   *string
+	Accepted   int
+	Actual     string
+	Delta      *float64
+	Distinct   int
+	Diverged   int
+	Errored    bool
+	Expected   string
+	Hint       string
+	Input      string
+	Input2     *string
+	Leaked     int
+	Matched    int
+	Memory     *Memory
+	Mismatch   string
+	Modified   bool
+	MoreInputs []string
+	Ok         bool
+	Panic      *PanicInfo
+	Steps      bool
+	TimedOut   bool
+	Timing     *Timing
 }
 
 // Constructs ReceiptLine for a single input problem
@@ -47,16 +187,93 @@ func NewReceiptLine2(input1, input2, actual, expected string) *ReceiptLine {
 	return NewReceiptLineImpl(actual, expected, input1, &input2)
 }
 
-// Internal constructor for ReceiptLine
+// Internal constructor for ReceiptLine.
+// The line is ok if actual and expected strings are equal.
 func NewReceiptLineImpl(actual, expected, input1 string, input2 *string) *ReceiptLine {
-	return &ReceiptLine{Actual: actual, Expected: expected, Input: input1, Input2: input2}
+	return &ReceiptLine{
+		Accepted:   0,
+		Actual:     actual,
+		Delta:      nil,
+		Distinct:   0,
+		Diverged:   0,
+		Errored:    false,
+		Expected:   expected,
+		Hint:       "",
+		Input:      input1,
+		Input2:     input2,
+		Leaked:     0,
+		Matched:    0,
+		Memory:     nil,
+		Mismatch:   "",
+		Modified:   false,
+		MoreInputs: nil,
+		Ok:         actual == expected,
+		Panic:      nil,
+		Steps:      false,
+		TimedOut:   false,
+		Timing:     nil,
+	}
+}
+
+// Returns a one line description of an error returned by a solution
+func ErrorString(err error) string {
+	return "error: " + err.Error()
+}
+
+// Constructs ReceiptLine for a solution that returned an error
+func NewErrorReceiptLine(
+	err error, expected, input1 string, input2 *string,
+) *ReceiptLine {
+	res := NewReceiptLineImpl(ErrorString(err), expected, input1, input2)
+	res.Errored = true
+	return res
+}
+
+// Constructs ReceiptLine for a solution that panicked
+func NewPanicReceiptLine(
+	p *PanicInfo, expected, input1 string, input2 *string,
+) *ReceiptLine {
+	res := NewReceiptLineImpl(p.String(), expected, input1, input2)
+	res.Ok = false
+	res.Panic = p
+	return res
+}
+
+// Constructs ReceiptLine for a solution that exceeded the time limit
+func NewTimeoutReceiptLine(
+	limit time.Duration, expected, input1 string, input2 *string,
+) *ReceiptLine {
+	actual := fmt.Sprintf("time limit exceeded (%v)", limit)
+	res := NewReceiptLineImpl(actual, expected, input1, input2)
+	res.Ok = false
+	res.TimedOut = true
+	return res
 }
 
 // Writes itself to builder using a new IteratorCollection
 // Returns a flag indicating whether a newline character was written
 func (r *ReceiptLine) ContinueBuild(builder *strings.Builder) bool {
-	col := NewIteratorCollection(r.Actual, r.Expected, r.Input, r.Input2)
-	return WriteCollection(builder, col)
+	col := NewIteratorCollection(
+		r.Actual, r.Expected, r.Input, r.Input2, r.IsOk(), r.TimedOut)
+	col.AddInputs(r.MoreInputs)
+	col.Diverged = r.Diverged
+	col.Errored = r.Errored
+	col.Mismatch = r.Mismatch
+	col.Steps = r.Steps
+	col.Suffix = r.measurements()
+
+	multiLine := WriteCollection(builder, col)
+
+	if r.Panic == nil {
+		return multiLine
+	}
+
+	for _, frame := range r.Panic.Stack {
+		builder.WriteString("\n     ")
+		builder.WriteString(frame)
+	}
+
+	return multiLine || len(r.Panic.Stack) > 0
 }
 
 // Returns a flag indicating whether two ReceiptLines match
@@ -72,7 +289,62 @@ func (r *ReceiptLine) Equal(o *ReceiptLine) bool {
 	}
 
 	return r.Actual == o.Actual && r.Expected == o.Expected &&
-		r.Input == o.Input && i2
+		r.Input == o.Input && i2 && slices.Equal(r.MoreInputs, o.MoreInputs)
+}
+
+// Returns the modification of inputs, leaked goroutines,
+// different outputs, the matched output, the difference of floats,
+// the hint, timing and memory of the line enclosed in brackets
+// or an empty string if none is available
+func (r *ReceiptLine) measurements() string {
+	parts := make([]string, 0, 8)
+
+	if r.Modified {
+		parts = append(parts, "input was modified")
+	}
+
+	if r.Leaked > 0 {
+		parts = append(parts, fmt.Sprintf("%d leaked goroutines", r.Leaked))
+	}
+
+	if r.Distinct > 1 {
+		parts = append(parts, fmt.Sprintf("%d distinct outputs", r.Distinct))
+	}
+
+	if r.Matched > 0 {
+		parts = append(parts, fmt.Sprintf("matched %d of %d", r.Matched, r.Accepted))
+	}
+
+	if r.Delta != nil {
+		parts = append(parts, fmt.Sprintf("delta %.4g", *r.Delta))
+	}
+
+	if r.Hint != "" {
+		parts = append(parts, r.Hint)
+	}
+
+	if r.Timing != nil {
+		parts = append(parts, r.Timing.String())
+	}
+
+	if r.Memory != nil {
+		parts = append(parts, r.Memory.String())
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("  [%s]", strings.Join(parts, ", "))
+}
+
+// Returns true if the solution did not panic, finished in time,
+// did not modify its inputs, left no goroutines running,
+// returned the same output on every run
+// and its actual output matches the expected one
+func (r *ReceiptLine) IsOk() bool {
+	return r.Panic == nil && !r.TimedOut && !r.Modified &&
+		r.Leaked == 0 && r.Distinct <= 1 && r.Ok
 }
 
 // Returns a string representation of the line
@@ -87,7 +359,9 @@ type ReceiptSlice struct {
 	Receipts []Receipt
 }
 
-// Writes itself to builder
+// Writes itself to builder.
+// Tables of measured times and allocations are appended if available.
+// If any solution panicked or timed out, a summary is appended.
 func (s *ReceiptSlice) ContinueBuild(builder *strings.Builder) {
 	last := len(s.Receipts) - 1
 
@@ -97,16 +371,162 @@ func (s *ReceiptSlice) ContinueBuild(builder *strings.Builder) {
 	}
 
 	s.Receipts[last].ContinueBuild(builder)
+	s.writeRanking(builder)
+	s.writeMemory(builder)
+	s.writeSummary(builder)
+}
+
+// Writes a table of solutions ordered by their total time
+// if the timing was enabled. Solutions measured only on some cases
+// are placed after the others.
+func (s *ReceiptSlice) writeRanking(builder *strings.Builder) {
+	timed := s.filter(func(r *Receipt) bool {
+		return r.Timing != nil
+	})
+
+	slices.SortStableFunc(timed, compareSpeed)
+
+	writeTable(builder, "Ranking", timed, func(r *Receipt) string {
+		if r.Timed < len(r.Lines) {
+			return fmt.Sprintf(
+				"%s (%d of %d cases)", r.Timing, r.Timed, len(r.Lines))
+		}
+
+		return r.Timing.String()
+	})
+}
+
+// Writes a table of solutions ordered by their total allocated bytes
+// if the memory was measured. Solutions whose memory was skipped
+// are placed after the others.
+func (s *ReceiptSlice) writeMemory(builder *strings.Builder) {
+	measured := s.filter(func(r *Receipt) bool {
+		return r.Memory != nil
+	})
+
+	slices.SortStableFunc(measured, func(a, b *Receipt) int {
+		return cmp.Or(
+			cmp.Compare(a.Memory.Bytes, b.Memory.Bytes),
+			cmp.Compare(a.Memory.Allocs, b.Memory.Allocs))
+	})
+
+	measured = append(measured, s.filter(func(r *Receipt) bool {
+		return r.MemorySkipped
+	})...)
+
+	writeTable(builder, "Memory", measured, func(r *Receipt) string {
+		if r.MemorySkipped {
+			return "not measured, called by reflection"
+		}
+
+		return r.Memory.String()
+	})
+}
+
+// Compares receipts by the speed of their solutions.
+// Receipts measured on all cases come before the others,
+// because the times of the others are incomplete.
+func compareSpeed(a, b *Receipt) int {
+	if aComplete, bComplete := a.isComplete(), b.isComplete(); aComplete != bComplete {
+		if aComplete {
+			return -1
+		}
+
+		return 1
+	}
+
+	return cmp.Compare(a.speed(), b.speed())
 }
 
-func (r *ReceiptSlice) Len() int {
-	return len(r.Receipts)
+// Returns pointers to receipts for which keep returns true
+func (s *ReceiptSlice) filter(keep func(r *Receipt) bool) []*Receipt {
+	res := make([]*Receipt, 0, len(s.Receipts))
+
+	for i := range s.Receipts {
+		if r := &s.Receipts[i]; keep(r) {
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
+
+// Writes a numbered table of receipts under a title.
+// Nothing is written if there are no receipts.
+func writeTable(
+	builder *strings.Builder, title string, receipts []*Receipt,
+	value func(r *Receipt) string,
+) {
+	if len(receipts) == 0 {
+		return
+	}
+
+	width := 0
+
+	for _, r := range receipts {
+		width = max(width, len(r.Name))
+	}
+
+	builder.WriteString("\n\n")
+	builder.WriteString(title)
+	builder.WriteRune('\n')
+	builder.WriteString(strings.Repeat("=", len(title)))
+
+	for i, r := range receipts {
+		builder.WriteString(fmt.Sprintf(
+			"\n%d. %-*s  %s", i+1, width, r.Name, value(r)))
+	}
 }
 
-func (r *ReceiptSlice) Less(i, j int) bool {
-	return r.Receipts[i].Name <= r.Receipts[j].Name
+// Writes a line for every solution that panicked or timed out
+func (s *ReceiptSlice) writeSummary(builder *strings.Builder) {
+	wroteHeader := false
+
+	for _, r := range s.Receipts {
+		if r.Panicked == 0 && r.TimedOut == 0 {
+			continue
+		}
+
+		if !wroteHeader {
+			builder.WriteString("\n\nSummary\n=======")
+			wroteHeader = true
+		}
+
+		builder.WriteRune('\n')
+		builder.WriteString(r.Name)
+		builder.WriteString(":")
+
+		if r.TimedOut > 0 {
+			builder.WriteString(fmt.Sprintf(
+				" %d of %d cases timed out", r.TimedOut, len(r.Lines)))
+
+			if r.Panicked > 0 {
+				builder.WriteRune(',')
+			}
+		}
+
+		if r.Panicked > 0 {
+			builder.WriteString(fmt.Sprintf(
+				" %d of %d cases panicked", r.Panicked, len(r.Lines)))
+		}
+	}
 }
 
-func (r *ReceiptSlice) Swap(i, j int) {
-	r.Receipts[i], r.Receipts[j] = r.Receipts[j], r.Receipts[i]
+// Sorts receipts by the order. Receipts that are equal by the order
+// keep their relative positions.
+func (r *ReceiptSlice) Sort(order SolutionOrder) {
+	switch order {
+	case Alphabetical:
+		slices.SortStableFunc(r.Receipts, func(a, b Receipt) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+	case ByPassRate:
+		slices.SortStableFunc(r.Receipts, func(a, b Receipt) int {
+			return cmp.Compare(b.passRate(), a.passRate())
+		})
+	case BySpeed:
+		slices.SortStableFunc(r.Receipts, func(a, b Receipt) int {
+			return compareSpeed(&a, &b)
+		})
+	}
 }
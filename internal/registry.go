@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Order in which receipts of solutions are shown
+type SolutionOrder int
+
+const (
+	// Solutions are ordered by name
+	Alphabetical SolutionOrder = iota
+	// Solutions keep the order in which they were added
+	RegistrationOrder
+	// Solutions with a greater share of passed cases come first
+	ByPassRate
+	// Faster solutions come first
+	BySpeed
+)
+
+// Solutions stored under unique names
+// in the order in which they were added
+type Registry[T any] struct {
+	names     []string
+	solutions map[string]T
+}
+
+// Constructs an empty Registry
+func NewRegistry[T any]() *Registry[T] {
+	return &Registry[T]{
+		names:     make([]string, 0),
+		solutions: make(map[string]T),
+	}
+}
+
+// Adds a solution under name.
+// Returns an error if the name is already taken.
+func (g *Registry[T]) Add(name string, solution T) error {
+	if g.Has(name) {
+		return fmt.Errorf("solution %s already exists", name)
+	}
+
+	g.names = append(g.names, name)
+	g.solutions[name] = solution
+	return nil
+}
+
+// Returns the solution stored under name
+// and a flag indicating whether it exists
+func (g *Registry[T]) Get(name string) (T, bool) {
+	solution, exists := g.solutions[name]
+	return solution, exists
+}
+
+// Returns true if a solution is stored under name
+func (g *Registry[T]) Has(name string) bool {
+	_, exists := g.solutions[name]
+	return exists
+}
+
+// Returns the number of solutions
+func (g *Registry[T]) Len() int {
+	return len(g.names)
+}
+
+// Returns names of all solutions in the order in which they were added.
+// If alphabetical is true, the names are sorted instead.
+func (g *Registry[T]) Names(alphabetical bool) []string {
+	names := append([]string(nil), g.names...)
+
+	if alphabetical {
+		sort.Strings(names)
+	}
+
+	return names
+}
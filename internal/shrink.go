@@ -0,0 +1,300 @@
+package internal
+
+import (
+	"math"
+	r "reflect"
+	"slices"
+)
+
+// Maximum number of candidates tried while shrinking one counter-example
+const maxShrinkAttempts = 10000
+
+// Greedily replaces values with simpler variants as long as fails
+// returns true for them. Returns the simplest failing values found
+// and the number of successful shrinking steps.
+func Shrink(values []r.Value, fails func(values []r.Value) bool) ([]r.Value, int) {
+	attempts, steps := 0, 0
+
+	for attempts < maxShrinkAttempts {
+		improved := false
+
+		for i := 0; i < len(values) && !improved; i++ {
+			shrinkValue(values[i], func(candidate r.Value) bool {
+				if attempts >= maxShrinkAttempts {
+					return false
+				}
+
+				attempts++
+				next := slices.Clone(values)
+				next[i] = candidate
+
+				if fails(next) {
+					values, improved = next, true
+					return false
+				}
+
+				return true
+			})
+		}
+
+		if !improved {
+			break
+		}
+
+		steps++
+	}
+
+	return values, steps
+}
+
+// Returns an addressable copy of val.
+// Slices and maps are copied so that the copy can be changed
+// without affecting val.
+func copyValue(val r.Value) r.Value {
+	val = settable(val)
+	res := r.New(val.Type()).Elem()
+
+	switch val.Kind() {
+	case r.Slice:
+		if !val.IsNil() {
+			res.Set(r.MakeSlice(val.Type(), val.Len(), val.Len()))
+			r.Copy(res, val)
+		}
+	case r.Map:
+		if !val.IsNil() {
+			res.Set(r.MakeMapWithSize(val.Type(), val.Len()))
+			iter := val.MapRange()
+
+			for iter.Next() {
+				res.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+	default:
+		res.Set(val)
+	}
+
+	return res
+}
+
+// Returns candidates for shrinking of an integer, closest to zero first
+func shrinkInt(n int64) []int64 {
+	if n == 0 {
+		return nil
+	}
+
+	res := []int64{0}
+
+	if half := n / 2; half != 0 {
+		res = append(res, half)
+	}
+
+	if n < 0 {
+		res = append(res, -n, n+1)
+	} else if n > 1 {
+		res = append(res, n-1)
+	}
+
+	return res
+}
+
+// Returns candidates for shrinking of a float, closest to zero first
+func shrinkFloat(f float64) []float64 {
+	if f == 0 || math.IsNaN(f) {
+		return nil
+	}
+
+	res := []float64{0}
+
+	if t := math.Trunc(f); t != f && t != 0 {
+		res = append(res, t)
+	}
+
+	if f < 0 {
+		res = append(res, -f)
+	}
+
+	if half := f / 2; math.Abs(f) > 1 {
+		res = append(res, half)
+	}
+
+	return res
+}
+
+// Returns candidates for shrinking of an unsigned integer, smallest first
+func shrinkUint(n uint64) []uint64 {
+	if n == 0 {
+		return nil
+	}
+
+	res := []uint64{0}
+
+	if half := n / 2; half > 0 {
+		res = append(res, half)
+	}
+
+	if n-1 > n/2 {
+		res = append(res, n-1)
+	}
+
+	return res
+}
+
+// Passes simpler variants of val to yield until yield returns false.
+// Returns false if shrinking was stopped by yield.
+func shrinkValue(val r.Value, yield func(candidate r.Value) bool) bool {
+	val = settable(val)
+
+	try := func(change func(c r.Value)) bool {
+		c := copyValue(val)
+		change(c)
+		return yield(c)
+	}
+
+	switch val.Kind() {
+	case r.Bool:
+		if val.Bool() {
+			return try(func(c r.Value) { c.SetBool(false) })
+		}
+	case r.Int, r.Int8, r.Int16, r.Int32, r.Int64:
+		for _, n := range shrinkInt(val.Int()) {
+			if !val.OverflowInt(n) && !try(func(c r.Value) { c.SetInt(n) }) {
+				return false
+			}
+		}
+	case r.Uint, r.Uint8, r.Uint16, r.Uint32, r.Uint64, r.Uintptr:
+		for _, m := range shrinkUint(val.Uint()) {
+			if !try(func(c r.Value) { c.SetUint(m) }) {
+				return false
+			}
+		}
+	case r.Float32, r.Float64:
+		for _, f := range shrinkFloat(val.Float()) {
+			if !try(func(c r.Value) { c.SetFloat(f) }) {
+				return false
+			}
+		}
+	case r.String:
+		return shrinkString(val.String(), func(s string) bool {
+			return try(func(c r.Value) { c.SetString(s) })
+		})
+	case r.Slice:
+		return shrinkSlice(val, yield)
+	case r.Array:
+		for i := 0; i < val.Len(); i++ {
+			ok := shrinkValue(val.Index(i), func(e r.Value) bool {
+				return try(func(c r.Value) { settable(c.Index(i)).Set(e) })
+			})
+
+			if !ok {
+				return false
+			}
+		}
+	case r.Map:
+		if val.Len() == 0 {
+			return true
+		}
+
+		if !yield(r.MakeMap(val.Type())) {
+			return false
+		}
+
+		for _, key := range val.MapKeys() {
+			if !try(func(c r.Value) { c.SetMapIndex(key, r.Value{}) }) {
+				return false
+			}
+		}
+	case r.Pointer:
+		if val.IsNil() {
+			return true
+		}
+
+		if !yield(r.Zero(val.Type())) {
+			return false
+		}
+
+		return shrinkValue(val.Elem(), func(e r.Value) bool {
+			ptr := r.New(val.Type().Elem())
+			ptr.Elem().Set(e)
+			return yield(ptr)
+		})
+	case r.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			ok := shrinkValue(val.Field(i), func(f r.Value) bool {
+				return try(func(c r.Value) { settable(c.Field(i)).Set(f) })
+			})
+
+			if !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Passes shorter variants of a slice and then variants
+// with simpler elements to yield
+func shrinkSlice(val r.Value, yield func(candidate r.Value) bool) bool {
+	length := val.Len()
+
+	if length == 0 {
+		return true
+	}
+
+	part := func(begin, end int) r.Value {
+		res := r.MakeSlice(val.Type(), 0, length-(end-begin))
+		res = r.AppendSlice(res, val.Slice(0, begin))
+		return r.AppendSlice(res, val.Slice(end, length))
+	}
+
+	if !yield(r.MakeSlice(val.Type(), 0, 0)) {
+		return false
+	}
+
+	if length > 1 && (!yield(part(length/2, length)) || !yield(part(0, length/2))) {
+		return false
+	}
+
+	for i := 0; i < length; i++ {
+		if !yield(part(i, i+1)) {
+			return false
+		}
+	}
+
+	for i := 0; i < length; i++ {
+		ok := shrinkValue(val.Index(i), func(e r.Value) bool {
+			c := copyValue(val)
+			settable(c.Index(i)).Set(e)
+			return yield(c)
+		})
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Passes shorter variants of a string to yield
+func shrinkString(s string, yield func(s string) bool) bool {
+	if len(s) == 0 {
+		return true
+	}
+
+	if !yield("") {
+		return false
+	}
+
+	if len(s) > 1 && (!yield(s[:len(s)/2]) || !yield(s[len(s)/2:])) {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !yield(s[:i] + s[i+1:]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,16 +1,28 @@
 package internal
 
 import (
+	"errors"
+	"strings"
+	"sync"
+
 	at "github.com/Matej-Chmel/go-any-to-string"
 	dc "github.com/Matej-Chmel/go-deep-copy"
 )
 
-// Test case with one or two inputs and an output
+// Test case with one or two inputs and an output.
+// Alternatives are accepted outputs other than Expected.
+// ExpectedErr is set if the case expects an error instead of an output.
+// Strings representing the case are cached and safe for concurrent use.
 type TestCase[I any, I2 any, O any] struct {
+	computed       bool
 	expectedString string
+	inputColumns   []string
 	inputString    string
 	input2String   string
+	mutex          sync.Mutex
+	Alternatives   []*O
 	Expected       *O
+	ExpectedErr    error
 	Input          *I
 	Input2         *I2
 }
@@ -20,29 +32,151 @@ func NewTestCase[I any, I2 any, O any](
 	i1 *I, i2 *I2, o *O, isSingleInput bool) *TestCase[I, I2, O] {
 
 	res := &TestCase[I, I2, O]{
-		Expected: dc.DeepCopy(o),
-		Input:    dc.DeepCopy(i1),
-		Input2:   nil,
+		Alternatives: nil,
+		Expected:     dc.DeepCopy(o),
+		ExpectedErr:  nil,
+		Input:        DeepCopy(i1),
+		Input2:       nil,
 	}
 
 	if !isSingleInput {
-		res.Input2 = dc.DeepCopy(i2)
+		res.Input2 = DeepCopy(i2)
 	}
 
 	return res
 }
 
-// Lazy loads and returns string representing expected result
+// Constructs a test case without an expected output.
+// The output is computed later by a reference solution.
+func NewInputCase[I any, I2 any, O any](
+	i1 *I, i2 *I2, isSingleInput bool) *TestCase[I, I2, O] {
+
+	res := NewTestCase[I, I2, O](i1, i2, nil, isSingleInput)
+	res.computed = true
+	res.Expected = nil
+	return res
+}
+
+// Constructs a test case that expects an error
+func NewErrorCase[I any, I2 any, O any](
+	i1 *I, i2 *I2, err error, isSingleInput bool) *TestCase[I, I2, O] {
+
+	res := NewTestCase[I, I2, O](i1, i2, nil, isSingleInput)
+	res.Expected = nil
+	res.ExpectedErr = err
+	return res
+}
+
+// Constructs a test case with multiple accepted outputs.
+// Panics if no output is given.
+func NewAlternativesCase[I any, I2 any, O any](
+	i1 *I, i2 *I2, accepted []O, isSingleInput bool) *TestCase[I, I2, O] {
+
+	if len(accepted) == 0 {
+		panic(errors.New("at least one accepted output is required"))
+	}
+
+	res := NewTestCase(i1, i2, &accepted[0], isSingleInput)
+
+	for i := 1; i < len(accepted); i++ {
+		res.Alternatives = append(res.Alternatives, dc.DeepCopy(&accepted[i]))
+	}
+
+	return res
+}
+
+// Returns all accepted outputs starting with the expected one
+func (c *TestCase[I, I2, O]) GetAccepted() []*O {
+	if c.Expected == nil {
+		return nil
+	}
+
+	return append([]*O{c.Expected}, c.Alternatives...)
+}
+
+// Returns true if the actual error matches the expected one.
+// Errors match if errors.Is reports so or if their messages are equal.
+func (c *TestCase[I, I2, O]) MatchesError(err error) bool {
+	return err != nil && c.ExpectedErr != nil &&
+		(errors.Is(err, c.ExpectedErr) || err.Error() == c.ExpectedErr.Error())
+}
+
+// Returns true if the expected output is still to be computed
+func (c *TestCase[I, I2, O]) NeedsExpected() bool {
+	return c.Expected == nil && c.ExpectedErr == nil
+}
+
+// Forgets the expected output if it was computed by a reference solution
+func (c *TestCase[I, I2, O]) ResetExpected() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.computed {
+		c.Expected = nil
+		c.ExpectedErr = nil
+		c.expectedString = ""
+	}
+}
+
+// Sets the expected output computed by a reference solution
+func (c *TestCase[I, I2, O]) SetExpected(o *O) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.Expected = o
+	c.expectedString = ""
+}
+
+// Sets the expected error returned by a reference solution
+func (c *TestCase[I, I2, O]) SetExpectedError(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.ExpectedErr = err
+	c.expectedString = ""
+}
+
+// Lazy loads and returns string representing expected result.
+// Multiple accepted outputs are separated by a vertical bar.
 func (c *TestCase[I, I2, O]) GetExpectedString(o *at.Options) string {
-	if c.expectedString == "" {
-		c.expectedString = at.AnyToStringCustom(*c.Expected, o)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.expectedString == "" && c.ExpectedErr != nil {
+		c.expectedString = ErrorString(c.ExpectedErr)
+	} else if c.expectedString == "" {
+		accepted := c.GetAccepted()
+		parts := make([]string, len(accepted))
+
+		for i, expected := range accepted {
+			parts[i] = OutputString(*expected, o)
+		}
+
+		c.expectedString = strings.Join(parts, " | ")
 	}
 
 	return c.expectedString
 }
 
+// Lazy loads and returns strings representing each of the inputs
+// if the first input holds arguments of a solution with any number
+// of inputs. Otherwise returns nil.
+func (c *TestCase[I, I2, O]) GetInputColumns(o *at.Options) []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if args, ok := any(c.Input).(*Args); ok && c.inputColumns == nil {
+		c.inputColumns = args.Strings(o)
+	}
+
+	return c.inputColumns
+}
+
 // Lazy loads and returns string representing first input
 func (c *TestCase[I, I2, O]) GetInputString(o *at.Options) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.inputString == "" {
 		c.inputString = at.AnyToStringCustom(*c.Input, o)
 	}
@@ -52,6 +186,9 @@ func (c *TestCase[I, I2, O]) GetInputString(o *at.Options) string {
 
 // Lazy loads and returns string representing second input
 func (c *TestCase[I, I2, O]) GetInput2String(o *at.Options) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.input2String == "" && c.Input2 != nil {
 		c.input2String = at.AnyToStringCustom(*c.Input2, o)
 	}
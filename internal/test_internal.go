@@ -102,7 +102,7 @@ func (t *Tester) CheckStrings(skip int, actual, expected string) {
 
 	var builder strings.Builder
 
-	for _, m := range mismatches[:5] {
+	for _, m := range mismatches[:min(5, len(mismatches))] {
 		builder.WriteString(strconv.FormatInt(int64(m.index), 10))
 		builder.WriteString(fmt.Sprintf(": %q != %q\n", m.actual, m.expected))
 	}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Wall-clock time measured over one or more runs
+type Timing struct {
+	Median time.Duration
+	Min    time.Duration
+	Runs   int
+}
+
+// Constructs Timing from durations of individual runs
+func NewTiming(durations []time.Duration) *Timing {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	median := sorted[mid]
+
+	if len(sorted)%2 == 0 {
+		median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return &Timing{Median: median, Min: sorted[0], Runs: len(sorted)}
+}
+
+// Adds the times of o to t
+func (t *Timing) Add(o *Timing) {
+	t.Median += o.Median
+	t.Min += o.Min
+	t.Runs = max(t.Runs, o.Runs)
+}
+
+// Returns a string representation of the timing
+func (t *Timing) String() string {
+	if t.Runs <= 1 {
+		return FormatDuration(t.Min)
+	}
+
+	return fmt.Sprintf("min %s, median %s",
+		FormatDuration(t.Min), FormatDuration(t.Median))
+}
+
+// Formats d rounded to at most four significant digits
+func FormatDuration(d time.Duration) string {
+	for unit := time.Duration(1); unit < time.Hour; unit *= 10 {
+		if d < unit*10000 {
+			return d.Round(unit).String()
+		}
+	}
+
+	return d.Round(time.Second).String()
+}
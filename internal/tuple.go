@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"fmt"
+	r "reflect"
+	"strings"
+
+	at "github.com/Matej-Chmel/go-any-to-string"
+)
+
+// Output made of multiple values returned by a solution
+type Tuple interface {
+	Values() []any
+}
+
+// Returns a string representing an output.
+// Elements of a tuple are separated by a comma.
+func OutputString(output any, o *at.Options) string {
+	tuple, ok := output.(Tuple)
+
+	if !ok {
+		return at.AnyToStringCustom(output, o)
+	}
+
+	values := tuple.Values()
+	parts := make([]string, len(values))
+
+	for i, v := range values {
+		parts[i] = at.AnyToStringCustom(v, o)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Returns a function that calls f by reflection and converts
+// its results to O. Function f must take inputs of the given types
+// and return either O or the fields of struct O in order.
+// The results can be followed by an error.
+// Returns an error if f doesn't have such a signature.
+func WrapFunction[O any](
+	f any, inputs []r.Type,
+) (func(args []r.Value) (O, error), error) {
+	t := r.TypeOf(f)
+
+	if t == nil || t.Kind() != r.Func {
+		return nil, fmt.Errorf("solution must be a function, found %v", t)
+	}
+
+	if t.IsVariadic() || !hasInputs(t, inputs) {
+		return nil, fmt.Errorf("solution %v must take inputs %v", t, inputs)
+	}
+
+	outType := r.TypeFor[O]()
+	numOut := t.NumOut()
+	returnsErr := numOut > 0 && t.Out(numOut-1) == r.TypeFor[error]()
+
+	if returnsErr {
+		numOut--
+	}
+
+	isTuple := numOut != 1 || t.Out(0) != outType
+
+	if isTuple && !returnsFields(t, numOut, outType) {
+		return nil, fmt.Errorf(
+			"solution %v must return %v and optionally an error", t, outType)
+	}
+
+	fn := r.ValueOf(f)
+
+	return func(args []r.Value) (O, error) {
+		results := fn.Call(args)
+		var err error
+
+		if returnsErr && !results[numOut].IsNil() {
+			err = results[numOut].Interface().(error)
+		}
+
+		if !isTuple {
+			res, _ := results[0].Interface().(O)
+			return res, err
+		}
+
+		var res O
+		val := r.ValueOf(&res).Elem()
+
+		for i := 0; i < numOut; i++ {
+			val.Field(i).Set(results[i])
+		}
+
+		return res, err
+	}, nil
+}
+
+// Returns true if function type t takes inputs of the given types
+func hasInputs(t r.Type, inputs []r.Type) bool {
+	if t.NumIn() != len(inputs) {
+		return false
+	}
+
+	for i, input := range inputs {
+		if t.In(i) != input {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if the first numOut results of function type t
+// have the types of exported fields of struct type s in order
+func returnsFields(t r.Type, numOut int, s r.Type) bool {
+	if s.Kind() != r.Struct || s.NumField() != numOut {
+		return false
+	}
+
+	for i := 0; i < numOut; i++ {
+		if field := s.Field(i); !field.IsExported() || field.Type != t.Out(i) {
+			return false
+		}
+	}
+
+	return true
+}
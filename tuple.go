@@ -0,0 +1,34 @@
+package gointerview
+
+// Output of a solution returning two values
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Constructs a Pair
+func NewPair[A any, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+// Returns the elements of the pair
+func (p Pair[A, B]) Values() []any {
+	return []any{p.First, p.Second}
+}
+
+// Output of a solution returning three values
+type Triple[A any, B any, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Constructs a Triple
+func NewTriple[A any, B any, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{First: first, Second: second, Third: third}
+}
+
+// Returns the elements of the triple
+func (t Triple[A, B, C]) Values() []any {
+	return []any{t.First, t.Second, t.Third}
+}